@@ -0,0 +1,46 @@
+// Command backfill-order-items 기존 order_details.products JSON을 order_items 테이블로 옮긴다.
+//
+//	go run ./cmd/backfill-order-items -batch 500
+//
+// order_items가 이미 있는 주문은 건너뛰므로 여러 번 실행해도 안전하다. 옮긴 주문의 검색 문서도 다시 만들므로
+// 주문 서비스가 한 번 기동해 orders 스키마가 최신인 상태에서 실행한다.
+package main
+
+import (
+	"context"
+	"flag"
+	"orderfc/cmd/order/repository"
+	"orderfc/cmd/order/resource"
+	"orderfc/cmd/order/service"
+	"orderfc/config"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"orderfc/productclient"
+)
+
+func main() {
+	batchSize := flag.Int("batch", 500, "orders per batch")
+	flag.Parse()
+
+	cfg := config.LoadConfig()
+	log.SetupLogger()
+
+	db := resource.InitDB(cfg.Database)
+	if err := db.AutoMigrate(&models.OrderItem{}); err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to migrate order_items table")
+	}
+
+	productCatalog, err := productclient.NewCatalog(cfg.Product)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to create product catalog client")
+	}
+
+	orderRepository := repository.NewOrderRepository(db, nil, productCatalog, cfg.Product)
+	orderService := service.NewOrderService(orderRepository)
+
+	migrated, err := orderService.BackfillOrderItems(context.Background(), *batchSize)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Int("migrated", migrated).Msg("Order items backfill failed")
+	}
+	log.Logger.Info().Int("migrated", migrated).Msg("Order items backfill completed")
+}
@@ -0,0 +1,229 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"orderfc/cmd/order/usecase"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchOrders godoc
+// @Summary 주문 검색 (관리자)
+// @Description 모든 사용자의 주문을 필터와 cursor 기반 페이지네이션으로 조회합니다. admin 또는 support role이 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Produce json
+// @Param user_id query int false "주문한 사용자 ID"
+// @Param status query int false "주문 상태"
+// @Param from query string false "조회 시작 시각 (RFC3339 또는 YYYY-MM-DD)"
+// @Param to query string false "조회 종료 시각 (RFC3339는 미포함, YYYY-MM-DD는 해당 일자까지 포함)"
+// @Param min_amount query int false "최소 주문 금액 (minor unit)"
+// @Param max_amount query int false "최대 주문 금액 (minor unit)"
+// @Param payment_method query string false "결제 수단"
+// @Param product_id query int false "해당 상품을 포함한 주문만 조회"
+// @Param sort query string false "정렬 방향 (asc, desc)" default(desc)
+// @Param cursor query string false "이전 응답의 next_cursor"
+// @Param limit query int false "페이지 크기 (최대 100)" default(20)
+// @Success 200 {object} models.OrderHistoryPage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders [get]
+func (h *OrderHandler) SearchOrders(c *gin.Context) {
+	var params models.OrderHistoryParam
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil || userID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			return
+		}
+		params.UserID = userID
+	}
+	if err := bindOrderHistoryQuery(c, &params); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid admin order search query")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, err := h.OrderUsecase.SearchOrders(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCursor) || errors.Is(err, usecase.ErrInvalidSort) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Error().Err(err).Msg("Error searching orders")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// GetAdminOrderByID godoc
+// @Summary 주문 상세 조회 (관리자)
+// @Description 사용자와 관계없이 주문 한 건을 상품, 상태 이력, 배송 정보와 함께 조회합니다. admin 또는 support role이 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Success 200 {object} models.OrderHistoryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders/{id} [get]
+func (h *OrderHandler) GetAdminOrderByID(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	result, err := h.OrderUsecase.GetOrderByID(c.Request.Context(), 0, orderId, true)
+	if err != nil {
+		writeOrderError(c, err, "Error getting order by id for admin")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// OverrideOrderStatus godoc
+// @Summary 주문 상태 변경 (관리자)
+// @Description 주문 상태를 직접 변경합니다. 기본적으로 상태 머신이 허용하는 전이만 가능하며, force=true면 검증 없이 적용합니다. 취소/실패 주문은 force로도 되돌릴 수 없고, 결제 전 주문을 취소/실패로 바꾸면 stock.rollback을 발행합니다. 변경은 상태 이력과 order.status_changed 이벤트로 남습니다. admin role과 orders:write scope가 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Param body body models.AdminOrderStatusRequest true "변경할 상태와 사유"
+// @Success 200 {object} models.OrderHistoryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders/{id}/status [post]
+func (h *OrderHandler) OverrideOrderStatus(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	adminId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	var statusRequest models.AdminOrderStatusRequest
+	if err := c.ShouldBindJSON(&statusRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in status override request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.OrderUsecase.OverrideOrderStatus(c.Request.Context(), adminId, orderId, statusRequest)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidStatusOverride) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		writeOrderError(c, err, "Error overriding order status")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetOrderAuditLog godoc
+// @Summary 주문 감사 로그 조회 (관리자)
+// @Description 주문 생성, 변경, 상태 전이를 누가(사용자/관리자/consumer/job) 어떤 요청(HTTP request_id, Kafka topic/partition/offset)으로 했는지 기록 순서대로 조회합니다. admin 또는 support role이 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Success 200 {object} models.OrderAuditLogResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders/{id}/audit-log [get]
+func (h *OrderHandler) GetOrderAuditLog(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	result, err := h.OrderUsecase.GetOrderAuditLog(c.Request.Context(), orderId)
+	if err != nil {
+		writeOrderError(c, err, "Error getting order audit log")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// FullTextSearchOrders godoc
+// @Summary 주문 전문 검색 (관리자)
+// @Description 배송지 일부, 상품명/SKU, 수령인, 연락처, 사용자 ID로 주문을 검색합니다. 각 단어는 접두사로 AND 검색되며 결과는 관련도 순으로 정렬되고 일치 부분은 headline에 <mark>로 표시됩니다. admin 또는 support role이 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Produce json
+// @Param q query string true "검색어"
+// @Param user_id query int false "주문한 사용자 ID"
+// @Param limit query int false "페이지 크기 (최대 100)" default(20)
+// @Param offset query int false "이전 응답의 next_offset" default(0)
+// @Success 200 {object} models.OrderSearchPage
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders/search [get]
+func (h *OrderHandler) FullTextSearchOrders(c *gin.Context) {
+	params := models.OrderSearchParam{Query: c.Query("q")}
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		userID, err := strconv.ParseInt(userIDStr, 10, 64)
+		if err != nil || userID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user_id"})
+			return
+		}
+		params.UserID = userID
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		params.Limit = limit
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		offset, err := strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+			return
+		}
+		params.Offset = offset
+	}
+
+	results, err := h.OrderUsecase.FullTextSearchOrders(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidSearchQuery) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		log.Logger.Error().Err(err).Msg("Error searching orders by text")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,285 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"orderfc/cmd/order/repository"
+	"orderfc/cmd/order/usecase"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"orderfc/productclient"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetCart godoc
+// @Summary 장바구니 조회
+// @Description 인증된 사용자의 장바구니를 조회합니다. 담은 상품이 없으면 빈 장바구니를 반환합니다.
+// @Tags CART
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} models.Cart
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/cart [get]
+func (h *OrderHandler) GetCart(c *gin.Context) {
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	cart, err := h.OrderUsecase.GetCart(c.Request.Context(), userId)
+	if err != nil {
+		writeCartError(c, err, "Error getting cart")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// ReplaceCart godoc
+// @Summary 장바구니 교체
+// @Description 장바구니의 상품과 체크아웃 정보(통화, 결제 수단, 배송지, 쿠폰)를 요청 내용으로 교체합니다.
+// @Tags CART
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body models.UpdateCartRequest true "장바구니"
+// @Success 200 {object} models.Cart
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/cart [put]
+func (h *OrderHandler) ReplaceCart(c *gin.Context) {
+	var cartRequest models.UpdateCartRequest
+	if err := c.ShouldBindJSON(&cartRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in cart request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	cart, err := h.OrderUsecase.ReplaceCart(c.Request.Context(), userId, cartRequest)
+	if err != nil {
+		writeCartError(c, err, "Error replacing cart")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// ClearCart godoc
+// @Summary 장바구니 비우기
+// @Tags CART
+// @Security BearerAuth
+// @Produce json
+// @Success 204
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/cart [delete]
+func (h *OrderHandler) ClearCart(c *gin.Context) {
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.OrderUsecase.ClearCart(c.Request.Context(), userId); err != nil {
+		writeCartError(c, err, "Error clearing cart")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddCartItem godoc
+// @Summary 장바구니 상품 추가
+// @Description 상품을 장바구니에 담습니다. 이미 담긴 상품이면 수량을 더합니다.
+// @Tags CART
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body models.CartItemRequest true "상품"
+// @Success 200 {object} models.Cart
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/cart/items [post]
+func (h *OrderHandler) AddCartItem(c *gin.Context) {
+	var itemRequest models.CartItemRequest
+	if err := c.ShouldBindJSON(&itemRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in cart item request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	cart, err := h.OrderUsecase.AddCartItem(c.Request.Context(), userId, itemRequest)
+	if err != nil {
+		writeCartError(c, err, "Error adding cart item")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// UpdateCartItem godoc
+// @Summary 장바구니 상품 수량 변경
+// @Tags CART
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param product_id path int true "상품 ID"
+// @Param body body models.UpdateCartItemRequest true "수량"
+// @Success 200 {object} models.Cart
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/cart/items/{product_id} [put]
+func (h *OrderHandler) UpdateCartItem(c *gin.Context) {
+	productId, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	var itemRequest models.UpdateCartItemRequest
+	if err := c.ShouldBindJSON(&itemRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in cart item request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	cart, err := h.OrderUsecase.UpdateCartItem(c.Request.Context(), userId, productId, itemRequest.Quantity)
+	if err != nil {
+		writeCartError(c, err, "Error updating cart item")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// RemoveCartItem godoc
+// @Summary 장바구니 상품 삭제
+// @Tags CART
+// @Security BearerAuth
+// @Produce json
+// @Param product_id path int true "상품 ID"
+// @Success 200 {object} models.Cart
+// @Failure 400 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/cart/items/{product_id} [delete]
+func (h *OrderHandler) RemoveCartItem(c *gin.Context) {
+	productId, err := strconv.ParseInt(c.Param("product_id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	cart, err := h.OrderUsecase.RemoveCartItem(c.Request.Context(), userId, productId)
+	if err != nil {
+		writeCartError(c, err, "Error removing cart item")
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// CheckOutCart godoc
+// @Summary 장바구니 주문
+// @Description 장바구니를 현재 상품 가격으로 주문하고 장바구니를 비웁니다. 멱등성 토큰은 자동으로 발급되어 재시도해도 같은 주문이 반환됩니다.
+// @Tags CART
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body models.CartCheckoutRequest false "결제 수단/배송지 (생략 시 장바구니 값)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/cart/checkout [post]
+func (h *OrderHandler) CheckOutCart(c *gin.Context) {
+	var checkoutRequest models.CartCheckoutRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&checkoutRequest); err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid JSON format in cart checkout request")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	orderId, err := h.OrderUsecase.CheckOutCart(c.Request.Context(), userId, checkoutRequest)
+	if err != nil {
+		if writeCheckoutValidationError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecase.ErrCartEmpty), isPromotionError(err):
+			log.Logger.Info().Err(err).Msg("Cart cannot be checked out")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrIdempotencyInProgress),
+			errors.Is(err, usecase.ErrIdempotencyKeyReused),
+			errors.Is(err, usecase.ErrIdempotencyPreviousFail):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			writeCartError(c, err, "Error checking out cart")
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Order created successfully", "order_id": orderId})
+}
+
+// writeCartError 장바구니 처리 에러를 HTTP 상태 코드로 변환한다.
+func writeCartError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidCartItem),
+		errors.Is(err, usecase.ErrUnsupportedCurrency),
+		errors.Is(err, usecase.ErrInvalidShippingAddress):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrCartItemMissing):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, productclient.ErrNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, productclient.ErrUnavailable), errors.Is(err, productclient.ErrBadResponse):
+		log.Logger.Warn().Err(err).Msg(msg)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, repository.ErrCartConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		log.Logger.Info().Err(err).Msg(msg)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -3,10 +3,15 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"orderfc/cmd/order/service"
 	"orderfc/cmd/order/usecase"
 	"orderfc/infrastructure/log"
+	"orderfc/infrastructure/money"
 	"orderfc/models"
+	"orderfc/productclient"
+	"orderfc/promotion"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -27,7 +32,7 @@ func (h *OrderHandler) Ping() gin.HandlerFunc {
 
 // CheckOutOrder godoc
 // @Summary 주문 생성
-// @Description 인증된 사용자의 주문을 생성하고 order_id를 반환합니다.
+// @Description 인증된 사용자의 주문을 생성하고 order_id를 반환합니다. quote_token을 넣으면 만료 전까지 견적 단가로 주문하며, 단가를 생략한 상품은 견적 단가로 채웁니다.
 // @Tags ORDER
 // @Security BearerAuth
 // @Accept json
@@ -35,7 +40,10 @@ func (h *OrderHandler) Ping() gin.HandlerFunc {
 // @Param body body models.CheckoutRequest true "주문 요청"
 // @Success 201 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
 // @Router /api/v1/orders [post]
 func (h *OrderHandler) CheckOutOrder(c *gin.Context) {
 	var checkoutRequest models.CheckoutRequest
@@ -68,6 +76,33 @@ func (h *OrderHandler) CheckOutOrder(c *gin.Context) {
 
 	orderId, err := h.OrderUsecase.CheckOutOrder(c.Request.Context(), &checkoutRequest)
 	if err != nil {
+		if writeCheckoutValidationError(c, err) {
+			return
+		}
+		if errors.Is(err, usecase.ErrUnsupportedCurrency) {
+			log.Logger.Info().Err(err).Msg("Unsupported checkout currency")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidShippingAddress) {
+			log.Logger.Info().Err(err).Msg("Invalid shipping address in checkout request")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidQuote) {
+			log.Logger.Info().Err(err).Msg("Invalid quote token in checkout request")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrQuoteExpired) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		if isPromotionError(err) {
+			log.Logger.Info().Err(err).Msg("Coupon cannot be applied to checkout")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
 		if errors.Is(err, usecase.ErrIdempotencyInProgress) ||
 			errors.Is(err, usecase.ErrIdempotencyKeyReused) ||
 			errors.Is(err, usecase.ErrIdempotencyPreviousFail) {
@@ -75,22 +110,392 @@ func (h *OrderHandler) CheckOutOrder(c *gin.Context) {
 			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
 			return
 		}
-		log.Logger.Info().Err(err).Msg("Error checking out order")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeOrderError(c, err, "Error checking out order")
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{"message": "Order created successfully", "order_id": orderId})
 }
 
+// QuoteOrder godoc
+// @Summary 주문 견적
+// @Description 주문을 저장하지 않고 상품 검증과 금액(할인, 세금, 배송비)을 계산해 미리 보여줍니다. 요청 단가는 무시하고 현재 가격을 사용하며, 응답의 quote_token을 주문 생성 요청에 넣으면 만료 전까지 견적 단가와 환율이 유지됩니다.
+// @Tags ORDER
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body models.CheckoutRequest true "주문 요청 (items.price 생략 가능)"
+// @Success 200 {object} models.QuoteResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/orders/quote [post]
+func (h *OrderHandler) QuoteOrder(c *gin.Context) {
+	var checkoutRequest models.CheckoutRequest
+	if err := c.ShouldBindJSON(&checkoutRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in quote request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(checkoutRequest.Items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Items are required"})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+	checkoutRequest.UserID = userId
+
+	result, err := h.OrderUsecase.Quote(c.Request.Context(), &checkoutRequest)
+	if err != nil {
+		if writeCheckoutValidationError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecase.ErrUnsupportedCurrency), errors.Is(err, usecase.ErrInvalidShippingAddress):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case isPromotionError(err):
+			log.Logger.Info().Err(err).Msg("Coupon cannot be applied to quote")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			writeOrderError(c, err, "Error quoting order")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetOrderByID godoc
+// @Summary 주문 상세 조회
+// @Description 인증된 사용자의 주문 한 건을 상품, 상태 이력, 결제 수단, 배송지와 함께 조회합니다.
+// @Tags ORDER
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Success 200 {object} models.OrderHistoryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/orders/{id} [get]
+func (h *OrderHandler) GetOrderByID(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.OrderUsecase.GetOrderByID(c.Request.Context(), userId, orderId, false)
+	if err != nil {
+		writeOrderError(c, err, "Error getting order by id")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AmendOrder godoc
+// @Summary 주문 변경
+// @Description 결제 전(created) 주문의 상품/수량, 결제 수단, 배송지를 변경합니다. 생략한 필드는 기존 값을 유지하며 금액은 다시 계산됩니다.
+// @Tags ORDER
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Param body body models.AmendOrderRequest true "변경 내용"
+// @Success 200 {object} models.OrderHistoryResponse
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/orders/{id} [patch]
+func (h *OrderHandler) AmendOrder(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	var amendRequest models.AmendOrderRequest
+	if err := c.ShouldBindJSON(&amendRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in amend request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if amendRequest.Items != nil && len(amendRequest.Items) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Items are required"})
+		return
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.OrderUsecase.AmendOrder(c.Request.Context(), userId, orderId, amendRequest)
+	if err != nil {
+		if writeCheckoutValidationError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecase.ErrNothingToAmend), errors.Is(err, usecase.ErrInvalidShippingAddress):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case isPromotionError(err):
+			log.Logger.Info().Err(err).Msg("Coupon no longer applies to amended order")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		default:
+			writeOrderError(c, err, "Error amending order")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// Reorder godoc
+// @Summary 재주문
+// @Description 지난 주문의 상품을 현재 가격/재고로 다시 계산한 체크아웃 미리보기를 반환합니다. 금액(할인·세금·배송비 포함 total_amount)은 체크아웃과 같은 방식으로 계산합니다. 품절·삭제 상품과 가격 변경 여부를 표시하며, place_order가 true면 주문 가능한 상품만으로 바로 주문합니다.
+// @Tags ORDER
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "원 주문 ID"
+// @Param body body models.ReorderRequest false "재주문 옵션"
+// @Success 200 {object} models.ReorderPreview
+// @Success 201 {object} models.ReorderPreview
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 422 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Failure 503 {object} map[string]interface{}
+// @Router /api/v1/orders/{id}/reorder [post]
+func (h *OrderHandler) Reorder(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	var reorderRequest models.ReorderRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&reorderRequest); err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid JSON format in reorder request")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.OrderUsecase.Reorder(c.Request.Context(), userId, orderId, reorderRequest)
+	if err != nil {
+		if writeCheckoutValidationError(c, err) {
+			return
+		}
+		switch {
+		case errors.Is(err, usecase.ErrNothingToReorder), isPromotionError(err):
+			log.Logger.Info().Err(err).Msg("Order cannot be placed again")
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrUnsupportedCurrency), errors.Is(err, usecase.ErrInvalidShippingAddress):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrIdempotencyInProgress),
+			errors.Is(err, usecase.ErrIdempotencyKeyReused),
+			errors.Is(err, usecase.ErrIdempotencyPreviousFail):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			writeOrderError(c, err, "Error reordering")
+		}
+		return
+	}
+
+	if result.OrderID != 0 {
+		c.JSON(http.StatusCreated, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// CancelOrder godoc
+// @Summary 주문 취소
+// @Description 인증된 사용자가 본인 주문을 취소합니다. 취소 가능한 상태(created, processing)에서만 허용됩니다.
+// @Tags ORDER
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Param body body models.CancelOrderRequest false "취소 사유"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/v1/orders/{id}/cancel [post]
+func (h *OrderHandler) CancelOrder(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	var cancelRequest models.CancelOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&cancelRequest); err != nil {
+			log.Logger.Info().Err(err).Msg("Invalid JSON format in cancel request")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userId, ok := userIDFromContext(c)
+	if !ok {
+		return
+	}
+
+	err = h.OrderUsecase.CancelOrder(c.Request.Context(), userId, orderId, cancelRequest.Reason)
+	if err != nil {
+		writeOrderError(c, err, "Error cancelling order")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Order cancelled successfully", "order_id": orderId})
+}
+
+// ShipOrder godoc
+// @Summary 주문 출고 처리 (관리자)
+// @Description 결제 완료(completed) 주문을 운송장 정보와 함께 shipped 상태로 변경합니다. admin role이 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Param body body models.ShipOrderRequest true "운송장 정보"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 409 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders/{id}/ship [post]
+func (h *OrderHandler) ShipOrder(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	var shipRequest models.ShipOrderRequest
+	if err := c.ShouldBindJSON(&shipRequest); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid JSON format in ship request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.OrderUsecase.ShipOrder(c.Request.Context(), orderId, shipRequest)
+	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidShipment) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		writeOrderError(c, err, "Error shipping order")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Order shipped successfully", "order_id": orderId})
+}
+
+// GetOrderSaga godoc
+// @Summary 주문 saga 상태 조회 (관리자)
+// @Description 주문의 체크아웃 saga 상태와 정방향/보상 step 진행 내역을 조회합니다. admin 또는 support role이 필요합니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "주문 ID"
+// @Success 200 {object} models.OrderSaga
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Failure 404 {object} map[string]interface{}
+// @Failure 500 {object} map[string]interface{}
+// @Router /api/admin/v1/orders/{id}/saga [get]
+func (h *OrderHandler) GetOrderSaga(c *gin.Context) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order id"})
+		return
+	}
+
+	result, err := h.OrderUsecase.GetOrderSaga(c.Request.Context(), orderId)
+	if err != nil {
+		writeOrderError(c, err, "Error getting order saga")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// InvalidateProductCache godoc
+// @Summary 상품 캐시 무효화 (관리자)
+// @Description productfc에서 상품 정보가 바뀌었을 때 주문 서비스의 Redis 상품 캐시를 즉시 비웁니다.
+// @Tags ADMIN
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "상품 ID"
+// @Success 204
+// @Failure 400 {object} map[string]interface{}
+// @Failure 403 {object} map[string]interface{}
+// @Router /api/admin/v1/products/{id}/cache [delete]
+func (h *OrderHandler) InvalidateProductCache(c *gin.Context) {
+	productId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid product id")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product id"})
+		return
+	}
+
+	h.OrderUsecase.InvalidateProductCache(c.Request.Context(), productId)
+	c.Status(http.StatusNoContent)
+}
+
 // GetOrderHistoryByUserId godoc
 // @Summary 주문 내역 조회
-// @Description 인증된 사용자의 주문 내역을 조회합니다.
+// @Description 인증된 사용자의 주문 내역을 조회합니다. create_time, id 기준 keyset 페이지네이션을 사용하며 응답의 next_cursor를 cursor로 넘기면 다음 페이지를 조회합니다.
 // @Tags ORDER
 // @Security BearerAuth
 // @Produce json
 // @Param status query int false "주문 상태"
-// @Success 200 {array} models.Order
+// @Param from query string false "조회 시작 시각 (RFC3339 또는 YYYY-MM-DD, 포함)"
+// @Param to query string false "조회 종료 시각 (RFC3339는 미포함, YYYY-MM-DD는 해당 일자까지 포함)"
+// @Param min_amount query int false "최소 주문 금액 (minor unit)"
+// @Param max_amount query int false "최대 주문 금액 (minor unit)"
+// @Param payment_method query string false "결제 수단"
+// @Param product_id query int false "해당 상품을 포함한 주문만 조회"
+// @Param sort query string false "정렬 방향 (asc, desc)" default(desc)
+// @Param cursor query string false "이전 응답의 next_cursor"
+// @Param limit query int false "페이지 크기 (최대 100)" default(20)
+// @Success 200 {object} models.OrderHistoryPage
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
 // @Router /api/v1/orders/history [get]
@@ -111,19 +516,19 @@ func (h *OrderHandler) GetOrderHistoryByUserId(c *gin.Context) {
 	}
 	params.UserID = int64(userId)
 
-	statusStr := c.Query("status")
-	if statusStr != "" {
-		status, err := strconv.Atoi(statusStr)
-		if err != nil {
-			log.Logger.Info().Err(err).Msg("Invalid status")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
-			return
-		}
-		params.Status = status
+	if err := bindOrderHistoryQuery(c, &params); err != nil {
+		log.Logger.Info().Err(err).Msg("Invalid order history query")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	results, err := h.OrderUsecase.GetOrderHistoryByUserId(c.Request.Context(), params)
 	if err != nil {
+		if errors.Is(err, usecase.ErrInvalidCursor) || errors.Is(err, usecase.ErrInvalidSort) {
+			log.Logger.Info().Err(err).Msg("Invalid order history pagination")
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		log.Logger.Info().Err(err).Msg("Error getting order history by user id")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -139,6 +544,7 @@ func (h *OrderHandler) GetOrderHistoryByUserId(c *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param days query int false "조회 기간(일)" default(30)
+// @Param currency query string false "리포트 통화 (기본: 스토어 기준 통화)"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]interface{}
 // @Failure 500 {object} map[string]interface{}
@@ -151,8 +557,12 @@ func (h *OrderHandler) GetSalesReport(c *gin.Context) {
 		return
 	}
 
-	results, err := h.OrderUsecase.GetDailySalesReport(c.Request.Context(), days)
+	results, err := h.OrderUsecase.GetDailySalesReport(c.Request.Context(), days, c.Query("currency"))
 	if err != nil {
+		if errors.Is(err, usecase.ErrUnsupportedCurrency) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		log.Logger.Error().Err(err).Msg("Error getting sales report")
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -164,3 +574,135 @@ func (h *OrderHandler) GetSalesReport(c *gin.Context) {
 		"note":   "Uses CTE + Window Functions (cumulative_revenue, revenue_rank)",
 	})
 }
+
+func userIDFromContext(c *gin.Context) (int64, bool) {
+	userIdStr, ok := c.Get("user_id")
+	if !ok {
+		log.Logger.Info().Msg("User ID not found in context")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "User ID not found in context"})
+		return 0, false
+	}
+	userId, ok := userIdStr.(float64)
+	if !ok {
+		log.Logger.Info().Msg("Invalid user ID")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID"})
+		return 0, false
+	}
+	return int64(userId), true
+}
+
+// writeOrderError 주문 단건 처리 에러를 HTTP 상태 코드로 변환한다.
+func writeOrderError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrOrderAccessDenied):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidStatusTransition),
+		errors.Is(err, service.ErrOrderNotAmendable),
+		errors.Is(err, service.ErrOrderModified):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, productclient.ErrNotFound):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, productclient.ErrUnavailable), errors.Is(err, productclient.ErrBadResponse):
+		log.Logger.Warn().Err(err).Msg(msg)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		log.Logger.Info().Err(err).Msg(msg)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// writeCheckoutValidationError 상품 검증 실패면 문제 상품 목록과 함께 422로 응답하고 true를 반환한다.
+func writeCheckoutValidationError(c *gin.Context, err error) bool {
+	var validationErr *usecase.CheckoutValidationError
+	if !errors.As(err, &validationErr) {
+		return false
+	}
+	log.Logger.Info().Err(err).Int("items", len(validationErr.Items)).Msg("Checkout items failed validation")
+	c.JSON(http.StatusUnprocessableEntity, gin.H{"error": validationErr.Error(), "items": validationErr.Items})
+	return true
+}
+
+// bindOrderHistoryQuery 주문 내역 필터/정렬/페이지네이션 query string을 파싱한다.
+func bindOrderHistoryQuery(c *gin.Context, params *models.OrderHistoryParam) error {
+	if statusStr := c.Query("status"); statusStr != "" {
+		status, err := strconv.Atoi(statusStr)
+		if err != nil {
+			return errors.New("Invalid status")
+		}
+		params.Status = &status
+	}
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, _, err := parseTimeQuery(fromStr)
+		if err != nil {
+			return errors.New("Invalid from")
+		}
+		params.From = &from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, dateOnly, err := parseTimeQuery(toStr)
+		if err != nil {
+			return errors.New("Invalid to")
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		params.To = &to
+	}
+	if minStr := c.Query("min_amount"); minStr != "" {
+		minAmount, err := strconv.ParseInt(minStr, 10, 64)
+		if err != nil {
+			return errors.New("Invalid min_amount")
+		}
+		amount := money.Amount(minAmount)
+		params.MinAmount = &amount
+	}
+	if maxStr := c.Query("max_amount"); maxStr != "" {
+		maxAmount, err := strconv.ParseInt(maxStr, 10, 64)
+		if err != nil {
+			return errors.New("Invalid max_amount")
+		}
+		amount := money.Amount(maxAmount)
+		params.MaxAmount = &amount
+	}
+	if productStr := c.Query("product_id"); productStr != "" {
+		productID, err := strconv.ParseInt(productStr, 10, 64)
+		if err != nil || productID <= 0 {
+			return errors.New("Invalid product_id")
+		}
+		params.ProductID = &productID
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return errors.New("Invalid limit")
+		}
+		params.Limit = limit
+	}
+	params.PaymentMethod = c.Query("payment_method")
+	params.Sort = c.Query("sort")
+	params.Cursor = c.Query("cursor")
+	return nil
+}
+
+// parseTimeQuery RFC3339 또는 YYYY-MM-DD 형식을 허용한다. 두 번째 반환값은 날짜만 주어졌는지 여부.
+func parseTimeQuery(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return t, true, nil
+}
+
+func isPromotionError(err error) bool {
+	return errors.Is(err, promotion.ErrCouponNotFound) ||
+		errors.Is(err, promotion.ErrCouponExpired) ||
+		errors.Is(err, promotion.ErrMinSpendNotMet) ||
+		errors.Is(err, promotion.ErrUsageLimitReached) ||
+		errors.Is(err, service.ErrAutoPromotionExhausted) ||
+		errors.Is(err, promotion.ErrCouponNotApplicable)
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (r *OrderRepository) DeleteOrderItemsTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return tx.WithContext(ctx).Table("order_items").Where("order_id = ?", orderID).Delete(&models.OrderItem{}).Error
+}
+
+func (r *OrderRepository) DeleteOrderTaxLinesTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return tx.WithContext(ctx).Table("order_tax_lines").Where("order_id = ?", orderID).Delete(&models.OrderTaxLine{}).Error
+}
+
+// UpdateAmendedOrderTx 주문 변경으로 다시 계산된 금액, 결제 수단, 배송지를 저장한다. 환율 스냅샷은 체크아웃 시점 값을 유지한다.
+func (r *OrderRepository) UpdateAmendedOrderTx(ctx context.Context, tx *gorm.DB, order *models.Order) error {
+	return tx.WithContext(ctx).Table("orders").Where("id = ?", order.ID).Updates(map[string]interface{}{
+		"amount":               order.Amount,
+		"base_amount":          order.BaseAmount,
+		"discount_amount":      order.DiscountAmount,
+		"tax_amount":           order.TaxAmount,
+		"base_tax_amount":      order.BaseTaxAmount,
+		"shipping_fee":         order.ShippingFee,
+		"total_qty":            order.TotalQty,
+		"payment_method":       order.PaymentMethod,
+		"shipping_address":     order.ShippingAddress,
+		"shipping_recipient":   order.Shipping.Recipient,
+		"shipping_phone":       order.Shipping.Phone,
+		"shipping_line1":       order.Shipping.Line1,
+		"shipping_line2":       order.Shipping.Line2,
+		"shipping_city":        order.Shipping.City,
+		"shipping_postal_code": order.Shipping.PostalCode,
+		"shipping_country":     order.Shipping.Country,
+		"update_time":          time.Now(),
+	}).Error
+}
+
+func (r *OrderRepository) UpdateOrderDetailProductsTx(ctx context.Context, tx *gorm.DB, orderDetailID int64, products string) error {
+	return tx.WithContext(ctx).Table("order_details").Where("id = ?", orderDetailID).Update("products", products).Error
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"orderfc/models"
+
+	"gorm.io/gorm"
+)
+
+// order_audit_log 변경을 막는 트리거 함수. 감사 로그는 INSERT만 허용한다.
+const orderAuditLogAppendOnlyFunctionSQL = `CREATE OR REPLACE FUNCTION order_audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'order_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql`
+
+const orderAuditLogAppendOnlyTriggerSQL = `CREATE TRIGGER order_audit_log_append_only
+	BEFORE UPDATE OR DELETE ON order_audit_log
+	FOR EACH ROW EXECUTE FUNCTION order_audit_log_append_only()`
+
+// orderAuditLogTriggerLockKey 여러 replica가 동시에 기동해도 트리거 생성은 하나만 하도록 잡는 advisory lock 키.
+const orderAuditLogTriggerLockKey = 0x6f72646572617564
+
+// EnsureOrderAuditLogAppendOnly AutoMigrate 이후 호출한다. 트리거가 이미 있으면 다시 만들지 않아
+// 기동할 때마다 테이블에 DROP/CREATE TRIGGER 잠금을 잡지 않는다.
+func (r *OrderRepository) EnsureOrderAuditLogAppendOnly(ctx context.Context) error {
+	return r.WithTransaction(ctx, func(tx *gorm.DB) error {
+		tx = tx.WithContext(ctx)
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", orderAuditLogTriggerLockKey).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(orderAuditLogAppendOnlyFunctionSQL).Error; err != nil {
+			return err
+		}
+		var exists bool
+		err := tx.Raw(`
+			SELECT EXISTS (
+				SELECT 1 FROM pg_trigger
+				WHERE tgrelid = 'order_audit_log'::regclass AND tgname = 'order_audit_log_append_only' AND NOT tgisinternal
+			)
+		`).Scan(&exists).Error
+		if err != nil || exists {
+			return err
+		}
+		return tx.Exec(orderAuditLogAppendOnlyTriggerSQL).Error
+	})
+}
+
+func (r *OrderRepository) InsertOrderAuditLogTx(ctx context.Context, tx *gorm.DB, entry *models.OrderAuditLog) error {
+	return tx.WithContext(ctx).Create(entry).Error
+}
+
+// GetOrderAuditLogs 주문의 감사 로그를 기록 순서대로 조회한다.
+func (r *OrderRepository) GetOrderAuditLogs(ctx context.Context, orderID int64) ([]models.OrderAuditLog, error) {
+	var entries []models.OrderAuditLog
+	err := r.Database.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("id ASC").
+		Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"orderfc/models"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrCartConflict = errors.New("cart was modified concurrently")
+
+// 동시 변경으로 WATCH가 깨졌을 때 재시도 횟수
+const cartUpdateMaxAttempts = 5
+
+func cartKey(userID int64) string {
+	return fmt.Sprintf("cart:%d", userID)
+}
+
+// GetCart 장바구니가 없으면 빈 장바구니를 반환한다.
+func (r *OrderRepository) GetCart(ctx context.Context, userID int64) (*models.Cart, error) {
+	return getCart(ctx, r.Redis, userID)
+}
+
+// UpdateCart WATCH로 읽은 장바구니에 fn을 적용하고 ttl을 갱신해 저장한다. 비워진 장바구니는 키를 지운다.
+// fn이 에러를 반환하면 저장하지 않는다.
+func (r *OrderRepository) UpdateCart(ctx context.Context, userID int64, ttl time.Duration, fn func(cart *models.Cart) error) (*models.Cart, error) {
+	key := cartKey(userID)
+	var updated *models.Cart
+	txf := func(tx *redis.Tx) error {
+		cart, err := getCart(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if err := fn(cart); err != nil {
+			return err
+		}
+		cart.UpdatedAt = time.Now()
+
+		var payload []byte
+		if !cart.IsEmpty() {
+			payload, err = json.Marshal(cart)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if payload == nil {
+				pipe.Del(ctx, key)
+				return nil
+			}
+			pipe.Set(ctx, key, payload, ttl)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		updated = cart
+		return nil
+	}
+
+	for attempt := 0; attempt < cartUpdateMaxAttempts; attempt++ {
+		err := r.Redis.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+	return nil, ErrCartConflict
+}
+
+func (r *OrderRepository) DeleteCart(ctx context.Context, userID int64) error {
+	return r.Redis.Del(ctx, cartKey(userID)).Err()
+}
+
+func getCart(ctx context.Context, client redis.Cmdable, userID int64) (*models.Cart, error) {
+	payload, err := client.Get(ctx, cartKey(userID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return &models.Cart{UserID: userID, Items: []models.CartItem{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cart models.Cart
+	if err := json.Unmarshal(payload, &cart); err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"orderfc/infrastructure/constant"
 	"orderfc/infrastructure/log"
+	"orderfc/infrastructure/money"
 	"orderfc/models"
 	"time"
 
@@ -44,6 +47,77 @@ func (r *OrderRepository) InsertOrderDetailTx(ctx context.Context, tx *gorm.DB,
 	return err
 }
 
+func (r *OrderRepository) InsertOrderItemsTx(ctx context.Context, tx *gorm.DB, items []models.OrderItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Table("order_items").Create(&items).Error
+}
+
+func (r *OrderRepository) GetOrderItemsByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderItem, error) {
+	return r.GetOrderItemsByOrderIDsTx(ctx, r.Database, orderIDs)
+}
+
+func (r *OrderRepository) GetOrderItemsByOrderIDsTx(ctx context.Context, tx *gorm.DB, orderIDs []int64) (map[int64][]models.OrderItem, error) {
+	itemsByOrder := make(map[int64][]models.OrderItem, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return itemsByOrder, nil
+	}
+	var items []models.OrderItem
+	err := tx.WithContext(ctx).
+		Table("order_items").
+		Where("order_id IN ?", orderIDs).
+		Order("id ASC").
+		Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		itemsByOrder[item.OrderID] = append(itemsByOrder[item.OrderID], item)
+	}
+	return itemsByOrder, nil
+}
+
+func (r *OrderRepository) InsertOrderTaxLinesTx(ctx context.Context, tx *gorm.DB, taxLines []models.OrderTaxLine) error {
+	if len(taxLines) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Table("order_tax_lines").Create(&taxLines).Error
+}
+
+func (r *OrderRepository) GetOrderTaxLinesByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderTaxLine, error) {
+	taxLinesByOrder := make(map[int64][]models.OrderTaxLine, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return taxLinesByOrder, nil
+	}
+	var taxLines []models.OrderTaxLine
+	err := r.Database.WithContext(ctx).
+		Table("order_tax_lines").
+		Where("order_id IN ?", orderIDs).
+		Order("id ASC").
+		Find(&taxLines).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, taxLine := range taxLines {
+		taxLinesByOrder[taxLine.OrderID] = append(taxLinesByOrder[taxLine.OrderID], taxLine)
+	}
+	return taxLinesByOrder, nil
+}
+
+// GetOrdersWithoutItems order_items가 아직 없는 주문과 상세를 id 순으로 조회한다 (backfill용).
+func (r *OrderRepository) GetOrdersWithoutItems(ctx context.Context, afterID int64, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	err := r.Database.WithContext(ctx).
+		Preload("OrderDetail").
+		Where("orders.id > ?", afterID).
+		Where("NOT EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id)").
+		Order("orders.id ASC").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
 func (r *OrderRepository) InsertOrderOutboxEventsTx(ctx context.Context, tx *gorm.DB, events []models.OrderOutboxEvent) error {
 	if len(events) == 0 {
 		return nil
@@ -105,6 +179,14 @@ func (r *OrderRepository) MarkIdempotencyTokenFailed(ctx context.Context, idempo
 		}).Error
 }
 
+// ReleaseIdempotencyToken 주문 저장 전에 실패한 요청의 예약을 지워 같은 키로 다시 시도할 수 있게 한다.
+func (r *OrderRepository) ReleaseIdempotencyToken(ctx context.Context, idempotencyToken string) error {
+	return r.Database.WithContext(ctx).
+		Table("order_request_logs").
+		Where("idempotency_token = ? AND status = ?", idempotencyToken, models.IdempotencyStatusProcessing).
+		Delete(&models.OrderRequestLog{}).Error
+}
+
 func (r *OrderRepository) CheckIdempotencyToken(ctx context.Context, idempotencyToken string) (bool, error) {
 	var log models.OrderRequestLog
 	err := r.Database.WithContext(ctx).Table("order_request_logs").First(&log, "idempotency_token = ?", idempotencyToken).Error
@@ -166,50 +248,225 @@ func (r *OrderRepository) MarkOutboxEventFailed(ctx context.Context, eventID int
 }
 
 func (r *OrderRepository) GetOrderHistoryByUserId(ctx context.Context, params models.OrderHistoryParam) ([]models.OrderHistoryResponse, error) {
+	return r.findOrderHistory(ctx, params, r.Database.WithContext(ctx).Where("user_id = ?", params.UserID))
+}
+
+// SearchOrders 관리자용 주문 검색. UserID가 0이면 전체 사용자의 주문을 대상으로 한다.
+func (r *OrderRepository) SearchOrders(ctx context.Context, params models.OrderHistoryParam) ([]models.OrderHistoryResponse, error) {
+	query := r.Database.WithContext(ctx)
+	if params.UserID != 0 {
+		query = query.Where("user_id = ?", params.UserID)
+	}
+	return r.findOrderHistory(ctx, params, query)
+}
+
+func (r *OrderRepository) findOrderHistory(ctx context.Context, params models.OrderHistoryParam, query *gorm.DB) ([]models.OrderHistoryResponse, error) {
 	var queryResults []models.OrderHistoryResult
-	query := r.Database.WithContext(ctx).Table("orders").
+	query = query.Table("orders").
 		Select("orders.*, order_details.products, order_details.order_history").
-		Joins("JOIN order_details ON orders.order_detail_id = order_details.id").
-		Where("user_id = ?", params.UserID)
+		Joins("JOIN order_details ON orders.order_detail_id = order_details.id")
 
-	if params.Status > 0 {
-		query = query.Where("status = ?", params.Status)
+	if params.Status != nil {
+		query = query.Where("status = ?", *params.Status)
+	}
+	if params.From != nil {
+		query = query.Where("orders.create_time >= ?", formatTimestamp(*params.From))
+	}
+	if params.To != nil {
+		query = query.Where("orders.create_time < ?", formatTimestamp(*params.To))
+	}
+	if params.MinAmount != nil {
+		query = query.Where("orders.amount >= ?", *params.MinAmount)
+	}
+	if params.MaxAmount != nil {
+		query = query.Where("orders.amount <= ?", *params.MaxAmount)
+	}
+	if params.PaymentMethod != "" {
+		query = query.Where("orders.payment_method = ?", params.PaymentMethod)
+	}
+	if params.ProductID != nil {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items oi WHERE oi.order_id = orders.id AND oi.product_id = ?)", *params.ProductID)
 	}
-	err := query.Order("orders.id DESC").Scan(&queryResults).Error
+
+	direction := "DESC"
+	comparator := "<"
+	if params.Sort == models.OrderHistorySortAsc {
+		direction = "ASC"
+		comparator = ">"
+	}
+	if params.After != nil {
+		query = query.Where(
+			fmt.Sprintf("(orders.create_time, orders.id) %s (?::timestamp, ?)", comparator),
+			formatTimestamp(params.After.CreateTime), params.After.ID,
+		)
+	}
+	if params.Limit > 0 {
+		query = query.Limit(params.Limit)
+	}
+	err := query.Order(fmt.Sprintf("orders.create_time %s, orders.id %s", direction, direction)).Scan(&queryResults).Error
 	if err != nil {
 		return nil, err
 	}
+	orderIDs := make([]int64, 0, len(queryResults))
+	for _, result := range queryResults {
+		orderIDs = append(orderIDs, result.Id)
+	}
+	itemsByOrder, err := r.GetOrderItemsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	discountsByOrder, err := r.GetOrderDiscountsByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	taxLinesByOrder, err := r.GetOrderTaxLinesByOrderIDs(ctx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	var results []models.OrderHistoryResponse
 	for _, result := range queryResults {
-		var products []models.CheckoutItem
-		var orderHistory []models.StatusHistory
-		err := json.Unmarshal([]byte(result.Products), &products)
+		response, err := toOrderHistoryResponse(result, itemsByOrder[result.Id], discountsByOrder[result.Id], taxLinesByOrder[result.Id])
 		if err != nil {
-			log.Logger.Info().Err(err).Msg("Error unmarshalling products")
 			return nil, err
 		}
-		err = json.Unmarshal([]byte(result.OrderHistory), &orderHistory)
-		if err != nil {
-			log.Logger.Info().Err(err).Msg("Error unmarshalling order history")
+		results = append(results, response)
+	}
+
+	return results, nil
+}
+
+func (r *OrderRepository) GetOrderHistoryByOrderID(ctx context.Context, orderID int64) (*models.OrderHistoryResponse, error) {
+	var queryResult models.OrderHistoryResult
+	err := r.Database.WithContext(ctx).Table("orders").
+		Select("orders.*, order_details.products, order_details.order_history").
+		Joins("JOIN order_details ON orders.order_detail_id = order_details.id").
+		Where("orders.id = ?", orderID).
+		Take(&queryResult).Error
+	if err != nil {
+		return nil, err
+	}
+	itemsByOrder, err := r.GetOrderItemsByOrderIDs(ctx, []int64{queryResult.Id})
+	if err != nil {
+		return nil, err
+	}
+	discountsByOrder, err := r.GetOrderDiscountsByOrderIDs(ctx, []int64{queryResult.Id})
+	if err != nil {
+		return nil, err
+	}
+	taxLinesByOrder, err := r.GetOrderTaxLinesByOrderIDs(ctx, []int64{queryResult.Id})
+	if err != nil {
+		return nil, err
+	}
+	response, err := toOrderHistoryResponse(queryResult, itemsByOrder[queryResult.Id], discountsByOrder[queryResult.Id], taxLinesByOrder[queryResult.Id])
+	if err != nil {
+		return nil, err
+	}
+	shipment, err := r.GetShipmentByOrderID(ctx, queryResult.Id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	response.Shipment = shipment
+	return &response, nil
+}
+
+// toOrderHistoryResponse order_items가 있으면 그것을 사용하고, backfill 전 주문은 products JSON으로 대체한다.
+func toOrderHistoryResponse(result models.OrderHistoryResult, items []models.OrderItem, discounts []models.OrderDiscount, taxLines []models.OrderTaxLine) (models.OrderHistoryResponse, error) {
+	products, err := OrderItemsToCheckoutItems(items, result.Products, result.Currency)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error unmarshalling products")
+		return models.OrderHistoryResponse{}, err
+	}
+	var orderHistory []models.StatusHistory
+	err = json.Unmarshal([]byte(result.OrderHistory), &orderHistory)
+	if err != nil {
+		log.Logger.Info().Err(err).Msg("Error unmarshalling order history")
+		return models.OrderHistoryResponse{}, err
+	}
+	var shipping *models.ShippingAddress
+	if !result.Shipping.IsZero() {
+		shipping = &result.Shipping
+	}
+	return models.OrderHistoryResponse{
+		OrderID:         result.Id,
+		UserID:          result.UserID,
+		TotalAmount:     result.Amount,
+		Currency:        result.Currency,
+		DiscountAmount:  result.DiscountAmount,
+		Discounts:       discounts,
+		TaxAmount:       result.TaxAmount,
+		TaxLines:        taxLines,
+		TotalQty:        result.TotalQty,
+		PaymentMethod:   result.PaymentMethod,
+		ShippingFee:     result.ShippingFee,
+		ShippingAddress: result.ShippingAddress,
+		Shipping:        shipping,
+		Products:        products,
+		History:         orderHistory,
+		Status:          constant.OrderStatusMap[result.Status],
+		CreateTime:      result.CreateTime,
+	}, nil
+}
+
+// legacyCheckoutItem order_items 도입 전 order_details.products JSON 형식. price는 currency의 major unit 소수다.
+type legacyCheckoutItem struct {
+	ProductID int64       `json:"product_id"`
+	Quantity  int         `json:"quantity"`
+	Price     json.Number `json:"price"`
+}
+
+// OrderItemsToCheckoutItems 응답 호환을 위해 order_items를 CheckoutItem 형태로 변환한다.
+// items가 비어 있으면 legacy products JSON을 파싱한다. order_items가 없는 주문은 모두 minor unit 전환 이전
+// 주문이므로 가격을 currency의 major unit으로 읽어 반올림한다.
+func OrderItemsToCheckoutItems(items []models.OrderItem, legacyProducts, currency string) ([]models.CheckoutItem, error) {
+	if len(items) == 0 {
+		var legacy []legacyCheckoutItem
+		if err := json.Unmarshal([]byte(legacyProducts), &legacy); err != nil {
 			return nil, err
 		}
-		results = append(results, models.OrderHistoryResponse{
-			OrderID:         result.Id,
-			TotalAmount:     result.Amount,
-			TotalQty:        result.TotalQty,
-			PaymentMethod:   result.PaymentMethod,
-			ShippingAddress: result.ShippingAddress,
-			Products:        products,
-			History:         orderHistory,
-			Status:          constant.OrderStatusMap[result.Status],
+		products := make([]models.CheckoutItem, 0, len(legacy))
+		for _, item := range legacy {
+			var price money.Amount
+			if item.Price != "" {
+				var err error
+				if price, err = money.ParseDecimal(item.Price.String(), currency); err != nil {
+					return nil, err
+				}
+			}
+			products = append(products, models.CheckoutItem{ProductID: item.ProductID, Quantity: item.Quantity, Price: price})
+		}
+		return products, nil
+	}
+	products := make([]models.CheckoutItem, 0, len(items))
+	for _, item := range items {
+		products = append(products, models.CheckoutItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     item.UnitPrice,
 		})
 	}
+	return products, nil
+}
 
-	return results, nil
+// formatTimestamp orders.create_time은 timezone 없는 timestamp(UTC)라 세션 timezone 변환을 피하려고 문자열로 비교한다.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.999999")
+}
+
+func (r *OrderRepository) GetOrderForUpdateTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.Order, error) {
+	var order models.Order
+	err := tx.WithContext(ctx).Table("orders").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", orderID).
+		First(&order).Error
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
 }
 
-func (r *OrderRepository) UpdateOrderStatus(ctx context.Context, orderID int64, status int) error {
-	err := r.Database.WithContext(ctx).Table("orders").Model(&models.Order{}).Where("id = ?", orderID).Updates(map[string]interface{}{
+func (r *OrderRepository) UpdateOrderStatusTx(ctx context.Context, tx *gorm.DB, orderID int64, status int) error {
+	err := tx.WithContext(ctx).Table("orders").Where("id = ?", orderID).Updates(map[string]interface{}{
 		"status":      status,
 		"update_time": time.Now(),
 	}).Error
@@ -220,6 +477,33 @@ func (r *OrderRepository) UpdateOrderStatus(ctx context.Context, orderID int64,
 	return nil
 }
 
+func (r *OrderRepository) AppendOrderHistoryTx(ctx context.Context, tx *gorm.DB, orderDetailID int64, entry models.StatusHistory) error {
+	var orderDetail models.OrderDetail
+	err := tx.WithContext(ctx).Table("order_details").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", orderDetailID).
+		First(&orderDetail).Error
+	if err != nil {
+		return err
+	}
+
+	var history []models.StatusHistory
+	if orderDetail.OrderHistory != "" {
+		if err := json.Unmarshal([]byte(orderDetail.OrderHistory), &history); err != nil {
+			return err
+		}
+	}
+	history = append(history, entry)
+
+	historyJson, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Table("order_details").
+		Where("id = ?", orderDetailID).
+		Update("order_history", string(historyJson)).Error
+}
+
 func (r *OrderRepository) GetOrderInfoByOrderID(ctx context.Context, orderID int64) (*models.Order, error) {
 	var order models.Order
 	err := r.Database.WithContext(ctx).Table("orders").Where("id = ?", orderID).First(&order).Error
@@ -230,14 +514,20 @@ func (r *OrderRepository) GetOrderInfoByOrderID(ctx context.Context, orderID int
 }
 
 func (r *OrderRepository) GetOrderDetailByID(ctx context.Context, orderDetailID int64) (*models.OrderDetail, error) {
+	return r.GetOrderDetailByIDTx(ctx, r.Database, orderDetailID)
+}
+
+func (r *OrderRepository) GetOrderDetailByIDTx(ctx context.Context, tx *gorm.DB, orderDetailID int64) (*models.OrderDetail, error) {
 	var orderDetail models.OrderDetail
-	err := r.Database.WithContext(ctx).Table("order_details").Where("id = ?", orderDetailID).First(&orderDetail).Error
+	err := tx.WithContext(ctx).Table("order_details").Where("id = ?", orderDetailID).First(&orderDetail).Error
 	if err != nil {
 		return nil, err
 	}
 	return &orderDetail, nil
 }
 
+// GetDailySalesReport 일자별 매출. 금액은 체크아웃 시점 기준 통화로 환산해 둔 base_amount(bigint minor unit) 합계이고,
+// 평균 주문 금액만 numeric 나눗셈 후 ROUND(half away from zero)로 minor unit에 맞춘다.
 func (r *OrderRepository) GetDailySalesReport(ctx context.Context, days int) ([]models.DailySalesReport, error) {
 	var results []models.DailySalesReport
 	query := `
@@ -245,8 +535,8 @@ func (r *OrderRepository) GetDailySalesReport(ctx context.Context, days int) ([]
 			SELECT
 				DATE(create_time) as sale_date,
 				COUNT(*) as order_count,
-				COALESCE(SUM(amount), 0) as total_revenue,
-				COALESCE(AVG(amount), 0) as avg_order_value,
+				COALESCE(SUM(base_amount), 0) as total_revenue,
+				COALESCE(SUM(base_tax_amount), 0) as total_tax,
 				COALESCE(SUM(total_qty), 0) as total_items
 			FROM orders
 			WHERE create_time >= NOW() - INTERVAL '1 day' * ?
@@ -255,10 +545,11 @@ func (r *OrderRepository) GetDailySalesReport(ctx context.Context, days int) ([]
 		SELECT
 			TO_CHAR(sale_date, 'YYYY-MM-DD') as sale_date,
 			order_count,
-			ROUND(total_revenue::numeric, 2) as total_revenue,
-			ROUND(avg_order_value::numeric, 2) as avg_order_value,
+			total_revenue::bigint as total_revenue,
+			total_tax::bigint as total_tax,
+			ROUND(total_revenue / order_count)::bigint as avg_order_value,
 			total_items,
-			ROUND(SUM(total_revenue) OVER (ORDER BY sale_date)::numeric, 2) as cumulative_revenue,
+			(SUM(total_revenue) OVER (ORDER BY sale_date))::bigint as cumulative_revenue,
 			ROW_NUMBER() OVER (ORDER BY total_revenue DESC) as revenue_rank
 		FROM daily_sales
 		ORDER BY sale_date DESC
@@ -266,3 +557,75 @@ func (r *OrderRepository) GetDailySalesReport(ctx context.Context, days int) ([]
 	err := r.Database.WithContext(ctx).Raw(query, days).Scan(&results).Error
 	return results, err
 }
+
+// ErrLegacyAmountPrecision 기준 통화 minor unit보다 작은 단위가 남은 기존 주문 금액이 있어 변환하지 않았다.
+var ErrLegacyAmountPrecision = errors.New("legacy order amount has more decimals than the base currency allows")
+
+// ConvertLegacyOrderAmounts 다중 통화 도입 전 orders.amount는 기준 통화 major unit numeric 컬럼이었다.
+// AutoMigrate가 bigint로 바꾸면 소수부가 잘리므로 그 전에 10^exponent를 곱해 minor unit으로 바꾼다.
+// 반올림이 필요한 금액이 하나라도 있으면 아무것도 바꾸지 않고 ErrLegacyAmountPrecision을 반환한다.
+func ConvertLegacyOrderAmounts(ctx context.Context, db *gorm.DB, baseCurrency string) error {
+	var dataType string
+	err := db.WithContext(ctx).Raw(`
+		SELECT data_type FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = 'orders' AND column_name = 'amount'
+	`).Scan(&dataType).Error
+	if err != nil || dataType != "numeric" {
+		return err
+	}
+
+	scale := int64(1)
+	for range money.Exponent(baseCurrency) {
+		scale *= 10
+	}
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var inexact int64
+		err := tx.Raw("SELECT COUNT(*) FROM orders WHERE amount * ? <> TRUNC(amount * ?)", scale, scale).Scan(&inexact).Error
+		if err != nil {
+			return err
+		}
+		if inexact > 0 {
+			return fmt.Errorf("%w: %d orders in %s", ErrLegacyAmountPrecision, inexact, baseCurrency)
+		}
+		// DDL은 바인드 파라미터를 받지 않는다. scale은 위에서 계산한 정수라 그대로 넣는다.
+		return tx.Exec(fmt.Sprintf("ALTER TABLE orders ALTER COLUMN amount TYPE bigint USING (amount * %d)::bigint", scale)).Error
+	})
+}
+
+// MigrateLegacyOrderCurrency 다중 통화 도입 전 주문은 모두 기준 통화였으므로 통화와 base_* 컬럼을 채운다.
+// amount는 ConvertLegacyOrderAmounts에서 이미 minor unit으로 바뀌어 기준 통화 금액과 같다.
+func (r *OrderRepository) MigrateLegacyOrderCurrency(ctx context.Context, baseCurrency string) error {
+	return r.Database.WithContext(ctx).
+		Table("orders").
+		Where("base_currency = ''").
+		Updates(map[string]interface{}{
+			"currency":      baseCurrency,
+			"base_currency": baseCurrency,
+			"base_amount":   gorm.Expr("amount"),
+			"exchange_rate": money.RateScale,
+		}).Error
+}
+
+// ClaimExpiredOrdersTx statuses 중 cutoff 이전에 생성된 주문을 잠근다. SKIP LOCKED라 여러 replica의 sweeper가
+// 동시에 돌아도 같은 주문을 중복 처리하지 않는다.
+func (r *OrderRepository) ClaimExpiredOrdersTx(ctx context.Context, tx *gorm.DB, statuses []int, cutoff time.Time, limit int) ([]models.Order, error) {
+	var orders []models.Order
+	err := tx.WithContext(ctx).Table("orders").
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status IN ? AND create_time < ?::timestamp", statuses, formatTimestamp(cutoff)).
+		Where("(expiry_retry_at IS NULL OR expiry_retry_at <= ?::timestamp)", formatTimestamp(time.Now())).
+		Order("create_time ASC, id ASC").
+		Limit(limit).
+		Find(&orders).Error
+	return orders, err
+}
+
+// DeferOrderExpiryTx 만료 처리에 실패한 주문의 시도 횟수를 올리고 retryAt까지 claim 대상에서 뺀다.
+func (r *OrderRepository) DeferOrderExpiryTx(ctx context.Context, tx *gorm.DB, orderID int64, retryAt time.Time) error {
+	return tx.WithContext(ctx).Table("orders").
+		Where("id = ?", orderID).
+		Updates(map[string]interface{}{
+			"expiry_attempts": gorm.Expr("expiry_attempts + 1"),
+			"expiry_retry_at": gorm.Expr("?::timestamp", formatTimestamp(retryAt)),
+		}).Error
+}
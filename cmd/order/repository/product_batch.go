@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"orderfc/productclient"
+	"sync"
+)
+
+const (
+	productBatchSize               = 100
+	defaultProductBatchConcurrency = 8
+)
+
+// GetProductInfos 여러 상품을 한 번에 조회한다. 캐시 miss는 productfc 배치 API(GET /v1/products?ids=)로,
+// 배치 API를 쓸 수 없으면 동시 조회 수를 제한한 개별 조회로 가져온다. 실패한 상품은 itemErrs에 ID별로 담는다.
+func (r *OrderRepository) GetProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error) {
+	productIDs = uniqueProductIDs(productIDs)
+	products := r.getCachedProducts(ctx, productIDs)
+
+	missing := make([]int64, 0, len(productIDs))
+	for _, productID := range productIDs {
+		if _, ok := products[productID]; !ok {
+			missing = append(missing, productID)
+		}
+	}
+	fetched, itemErrs := r.fetchProductInfos(ctx, missing)
+	r.cacheProducts(ctx, productValues(fetched))
+	for productID, product := range fetched {
+		products[productID] = product
+	}
+	return products, itemErrs
+}
+
+// RefreshProductInfos 캐시를 무시하고 productfc에서 다시 조회한 뒤 캐시를 갱신한다.
+func (r *OrderRepository) RefreshProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error) {
+	products, itemErrs := r.fetchProductInfos(ctx, uniqueProductIDs(productIDs))
+	r.cacheProducts(ctx, productValues(products))
+	notFound := make([]int64, 0)
+	for productID, err := range itemErrs {
+		if errors.Is(err, ErrProductNotFound) {
+			notFound = append(notFound, productID)
+		}
+	}
+	r.InvalidateProductCache(ctx, notFound...)
+	return products, itemErrs
+}
+
+func (r *OrderRepository) fetchProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error) {
+	products := make(map[int64]models.Product, len(productIDs))
+	itemErrs := make(map[int64]error)
+	if len(productIDs) == 0 {
+		return products, itemErrs
+	}
+
+	for start := 0; start < len(productIDs); start += productBatchSize {
+		end := min(start+productBatchSize, len(productIDs))
+		chunk := productIDs[start:end]
+
+		batch, err := r.ProductCatalog.GetProducts(ctx, chunk)
+		if errors.Is(err, productclient.ErrUnavailable) {
+			// 재시도까지 실패했거나 브레이커가 열린 상태라 개별 조회로 넘겨도 소용이 없다.
+			for _, productID := range chunk {
+				itemErrs[productID] = err
+			}
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, productclient.ErrBatchUnsupported) {
+				log.Logger.Warn().Err(err).Int("products", len(chunk)).Msg("Product batch lookup failed, falling back to concurrent lookups")
+			}
+			batch, chunkErrs := r.fetchProductsConcurrently(ctx, chunk)
+			for productID, product := range batch {
+				products[productID] = product
+			}
+			for productID, err := range chunkErrs {
+				itemErrs[productID] = err
+			}
+			continue
+		}
+		for _, productID := range chunk {
+			product, ok := batch[productID]
+			if !ok {
+				itemErrs[productID] = fmt.Errorf("%w: %d", ErrProductNotFound, productID)
+				continue
+			}
+			products[productID] = product
+		}
+	}
+	return products, itemErrs
+}
+
+// fetchProductsConcurrently 최대 ProductBatchConcurrency개의 요청을 동시에 보낸다.
+func (r *OrderRepository) fetchProductsConcurrently(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error) {
+	concurrency := r.ProductBatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultProductBatchConcurrency
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		products = make(map[int64]models.Product, len(productIDs))
+		itemErrs = make(map[int64]error)
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, productID := range productIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(productID int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			product, err := r.fetchProductInfo(ctx, productID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				itemErrs[productID] = err
+				return
+			}
+			products[productID] = product
+		}(productID)
+	}
+	wg.Wait()
+	return products, itemErrs
+}
+
+func uniqueProductIDs(productIDs []int64) []int64 {
+	seen := make(map[int64]bool, len(productIDs))
+	unique := make([]int64, 0, len(productIDs))
+	for _, productID := range productIDs {
+		if !seen[productID] {
+			seen[productID] = true
+			unique = append(unique, productID)
+		}
+	}
+	return unique
+}
+
+func productValues(products map[int64]models.Product) []models.Product {
+	values := make([]models.Product, 0, len(products))
+	for _, product := range products {
+		values = append(values, product)
+	}
+	return values
+}
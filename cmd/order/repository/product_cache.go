@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/redis/go-redis/v9"
+)
+
+// 상품 캐시 조회 결과 라벨
+const (
+	productCacheHit    = "hit"
+	productCacheMiss   = "miss"
+	productCacheError  = "error"
+	productCacheBypass = "bypass"
+)
+
+var productCacheRequests = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "commerce",
+		Subsystem: "product_cache",
+		Name:      "requests_total",
+		Help:      "Product cache lookups by result (hit, miss, error, bypass)",
+	},
+	[]string{"result"},
+)
+
+func productCacheKey(productID int64) string {
+	return fmt.Sprintf("product:%d", productID)
+}
+
+func (r *OrderRepository) productCacheEnabled() bool {
+	return r.Redis != nil && r.ProductCacheTTL > 0
+}
+
+// GetProductInfo Redis 캐시를 먼저 보고, 없으면 productfc에서 조회해 캐시에 채운다 (read-through).
+// 캐시된 재고는 최대 TTL만큼 늦을 수 있으므로 재고 판단이 필요한 곳은 RefreshProductInfo로 다시 확인한다.
+func (r *OrderRepository) GetProductInfo(ctx context.Context, productID int64) (models.Product, error) {
+	if !r.productCacheEnabled() {
+		productCacheRequests.WithLabelValues(productCacheBypass).Inc()
+		return r.fetchProductInfo(ctx, productID)
+	}
+
+	payload, err := r.Redis.Get(ctx, productCacheKey(productID)).Bytes()
+	switch {
+	case err == nil:
+		var product models.Product
+		if err := json.Unmarshal(payload, &product); err == nil {
+			productCacheRequests.WithLabelValues(productCacheHit).Inc()
+			return product, nil
+		}
+		productCacheRequests.WithLabelValues(productCacheError).Inc()
+	case errors.Is(err, redis.Nil):
+		productCacheRequests.WithLabelValues(productCacheMiss).Inc()
+	default:
+		// Redis 장애는 조회 실패로 만들지 않고 productfc로 넘긴다.
+		productCacheRequests.WithLabelValues(productCacheError).Inc()
+		log.Logger.Warn().Err(err).Int64("product_id", productID).Msg("Failed to read product cache")
+	}
+
+	return r.RefreshProductInfo(ctx, productID)
+}
+
+// RefreshProductInfo 캐시를 무시하고 productfc에서 조회한 뒤 캐시를 갱신한다.
+func (r *OrderRepository) RefreshProductInfo(ctx context.Context, productID int64) (models.Product, error) {
+	product, err := r.fetchProductInfo(ctx, productID)
+	if err != nil {
+		if errors.Is(err, ErrProductNotFound) {
+			r.InvalidateProductCache(ctx, productID)
+		}
+		return models.Product{}, err
+	}
+	r.cacheProducts(ctx, []models.Product{product})
+	return product, nil
+}
+
+// getCachedProducts MGET 한 번으로 캐시된 상품을 읽는다. 반환 map에 없는 ID는 캐시 miss다.
+func (r *OrderRepository) getCachedProducts(ctx context.Context, productIDs []int64) map[int64]models.Product {
+	products := make(map[int64]models.Product, len(productIDs))
+	if !r.productCacheEnabled() {
+		productCacheRequests.WithLabelValues(productCacheBypass).Add(float64(len(productIDs)))
+		return products
+	}
+
+	keys := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		keys = append(keys, productCacheKey(productID))
+	}
+	values, err := r.Redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		productCacheRequests.WithLabelValues(productCacheError).Add(float64(len(productIDs)))
+		log.Logger.Warn().Err(err).Msg("Failed to read product cache")
+		return products
+	}
+	for i, value := range values {
+		payload, ok := value.(string)
+		if !ok {
+			productCacheRequests.WithLabelValues(productCacheMiss).Inc()
+			continue
+		}
+		var product models.Product
+		if err := json.Unmarshal([]byte(payload), &product); err != nil {
+			productCacheRequests.WithLabelValues(productCacheError).Inc()
+			continue
+		}
+		productCacheRequests.WithLabelValues(productCacheHit).Inc()
+		products[productIDs[i]] = product
+	}
+	return products
+}
+
+func (r *OrderRepository) cacheProducts(ctx context.Context, products []models.Product) {
+	if !r.productCacheEnabled() || len(products) == 0 {
+		return
+	}
+	_, err := r.Redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, product := range products {
+			payload, err := json.Marshal(product)
+			if err != nil {
+				return err
+			}
+			pipe.Set(ctx, productCacheKey(product.ID), payload, r.ProductCacheTTL)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Logger.Warn().Err(err).Int("products", len(products)).Msg("Failed to write product cache")
+	}
+}
+
+// InvalidateProductCache 재고/가격이 바뀐 상품의 캐시를 지운다. 실패해도 TTL이 지나면 만료되므로 로그만 남긴다.
+func (r *OrderRepository) InvalidateProductCache(ctx context.Context, productIDs ...int64) {
+	if r.Redis == nil || len(productIDs) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		keys = append(keys, productCacheKey(productID))
+	}
+	if err := r.Redis.Del(ctx, keys...).Err(); err != nil {
+		log.Logger.Warn().Err(err).Ints64("product_ids", productIDs).Msg("Failed to invalidate product cache")
+	}
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *OrderRepository) GetPromotionsByCodes(ctx context.Context, codes []string) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	if len(codes) == 0 {
+		return promotions, nil
+	}
+	err := r.Database.WithContext(ctx).
+		Table("promotions").
+		Where("code IN ?", codes).
+		Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *OrderRepository) GetAutoApplyPromotions(ctx context.Context, now time.Time) ([]models.Promotion, error) {
+	var promotions []models.Promotion
+	err := r.Database.WithContext(ctx).
+		Table("promotions").
+		Where("auto_apply = ? AND active = ? AND start_time <= ? AND expire_time > ?", true, true, now, now).
+		Find(&promotions).Error
+	return promotions, err
+}
+
+// CountUserRedemptions 프로모션별 사용자의 활성 사용 횟수. excludeOrderID 주문의 사용 기록은 제외한다 (0이면 전체).
+func (r *OrderRepository) CountUserRedemptions(ctx context.Context, userID int64, promotionIDs []int64, excludeOrderID int64) (map[int64]int, error) {
+	counts := make(map[int64]int, len(promotionIDs))
+	if len(promotionIDs) == 0 {
+		return counts, nil
+	}
+	var rows []struct {
+		PromotionID int64
+		Count       int
+	}
+	err := r.Database.WithContext(ctx).
+		Table("coupon_redemptions").
+		Select("promotion_id, COUNT(*) as count").
+		Where("user_id = ? AND promotion_id IN ? AND status = ? AND order_id <> ?", userID, promotionIDs, models.CouponRedemptionStatusActive, excludeOrderID).
+		Group("promotion_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.PromotionID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *OrderRepository) GetPromotionForUpdateTx(ctx context.Context, tx *gorm.DB, promotionID int64) (*models.Promotion, error) {
+	var promotion models.Promotion
+	err := tx.WithContext(ctx).Table("promotions").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", promotionID).
+		First(&promotion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+func (r *OrderRepository) CountUserRedemptionsTx(ctx context.Context, tx *gorm.DB, promotionID, userID int64) (int64, error) {
+	var count int64
+	err := tx.WithContext(ctx).
+		Table("coupon_redemptions").
+		Where("promotion_id = ? AND user_id = ? AND status = ?", promotionID, userID, models.CouponRedemptionStatusActive).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *OrderRepository) InsertCouponRedemptionTx(ctx context.Context, tx *gorm.DB, redemption *models.CouponRedemption) error {
+	err := tx.WithContext(ctx).Table("promotions").
+		Where("id = ?", redemption.PromotionID).
+		Updates(map[string]interface{}{
+			"redeemed_count": gorm.Expr("redeemed_count + 1"),
+			"update_time":    time.Now(),
+		}).Error
+	if err != nil {
+		return err
+	}
+	return tx.WithContext(ctx).Table("coupon_redemptions").Create(redemption).Error
+}
+
+// ReleaseCouponRedemptionsTx 주문의 활성 사용 기록을 released로 바꾸고 프로모션 사용 횟수를 되돌린다.
+func (r *OrderRepository) ReleaseCouponRedemptionsTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	var redemptions []models.CouponRedemption
+	err := tx.WithContext(ctx).Table("coupon_redemptions").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ? AND status = ?", orderID, models.CouponRedemptionStatusActive).
+		Find(&redemptions).Error
+	if err != nil {
+		return err
+	}
+	for _, redemption := range redemptions {
+		err := tx.WithContext(ctx).Table("promotions").
+			Where("id = ? AND redeemed_count > 0", redemption.PromotionID).
+			Updates(map[string]interface{}{
+				"redeemed_count": gorm.Expr("redeemed_count - 1"),
+				"update_time":    time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.WithContext(ctx).Table("coupon_redemptions").
+			Where("id = ?", redemption.ID).
+			Updates(map[string]interface{}{
+				"status":      models.CouponRedemptionStatusReleased,
+				"update_time": time.Now(),
+			}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *OrderRepository) InsertOrderDiscountsTx(ctx context.Context, tx *gorm.DB, discounts []models.OrderDiscount) error {
+	if len(discounts) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Table("order_discounts").Create(&discounts).Error
+}
+
+func (r *OrderRepository) DeleteOrderDiscountsTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return tx.WithContext(ctx).Table("order_discounts").Where("order_id = ?", orderID).Delete(&models.OrderDiscount{}).Error
+}
+
+func (r *OrderRepository) GetOrderDiscountsByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderDiscount, error) {
+	discountsByOrder := make(map[int64][]models.OrderDiscount, len(orderIDs))
+	if len(orderIDs) == 0 {
+		return discountsByOrder, nil
+	}
+	var discounts []models.OrderDiscount
+	err := r.Database.WithContext(ctx).
+		Table("order_discounts").
+		Where("order_id IN ?", orderIDs).
+		Order("id ASC").
+		Find(&discounts).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, discount := range discounts {
+		discountsByOrder[discount.OrderID] = append(discountsByOrder[discount.OrderID], discount)
+	}
+	return discountsByOrder, nil
+}
@@ -2,56 +2,36 @@ package repository
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
-	"fmt"
-	"net/http"
+	"orderfc/config"
 	"orderfc/models"
+	"orderfc/productclient"
+	"time"
 
 	"github.com/redis/go-redis/v9"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/propagation"
 	"gorm.io/gorm"
 )
 
+var ErrProductNotFound = productclient.ErrNotFound
+
 type OrderRepository struct {
-	Database    *gorm.DB
-	Redis       *redis.Client
-	ProductHost string
+	Database                *gorm.DB
+	Redis                   *redis.Client
+	ProductCatalog          productclient.ProductCatalog
+	ProductCacheTTL         time.Duration
+	ProductBatchConcurrency int
 }
 
-func NewOrderRepository(db *gorm.DB, redis *redis.Client, productHost string) *OrderRepository {
+func NewOrderRepository(db *gorm.DB, redis *redis.Client, productCatalog productclient.ProductCatalog, productCfg config.ProductConfig) *OrderRepository {
 	return &OrderRepository{
-		Database:    db,
-		Redis:       redis,
-		ProductHost: productHost,
+		Database:                db,
+		Redis:                   redis,
+		ProductCatalog:          productCatalog,
+		ProductCacheTTL:         productCfg.CacheTTL,
+		ProductBatchConcurrency: productCfg.BatchConcurrency,
 	}
 }
 
-func (r *OrderRepository) GetProductInfo(ctx context.Context, productID int64) (models.Product, error) {
-
-	url := fmt.Sprintf("%s/v1/products/%d", r.ProductHost, productID)
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return models.Product{}, err
-	}
-	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return models.Product{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return models.Product{}, errors.New("failed to get product info")
-	}
-
-	var product models.Product
-	err = json.NewDecoder(resp.Body).Decode(&product)
-	if err != nil {
-		return models.Product{}, err
-	}
-
-	return product, nil
+// fetchProductInfo productfc에서 상품을 직접 조회한다. 캐시를 거치려면 GetProductInfo를 쓴다.
+func (r *OrderRepository) fetchProductInfo(ctx context.Context, productID int64) (models.Product, error) {
+	return r.ProductCatalog.GetProduct(ctx, productID)
 }
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *OrderRepository) InsertOrderSagaTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga) error {
+	if err := tx.WithContext(ctx).Table("order_sagas").Omit("Steps").Create(sg).Error; err != nil {
+		return err
+	}
+	for i := range sg.Steps {
+		sg.Steps[i].SagaID = sg.ID
+	}
+	if len(sg.Steps) == 0 {
+		return nil
+	}
+	return tx.WithContext(ctx).Table("order_saga_steps").Create(&sg.Steps).Error
+}
+
+// GetOrderSagaForUpdateTx saga 행을 잠그고 step을 생성 순서로 함께 읽는다.
+func (r *OrderRepository) GetOrderSagaForUpdateTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.OrderSaga, error) {
+	var sg models.OrderSaga
+	err := tx.WithContext(ctx).Table("order_sagas").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("order_id = ?", orderID).
+		Take(&sg).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadSagaSteps(ctx, tx, &sg); err != nil {
+		return nil, err
+	}
+	return &sg, nil
+}
+
+func (r *OrderRepository) GetOrderSaga(ctx context.Context, orderID int64) (*models.OrderSaga, error) {
+	var sg models.OrderSaga
+	err := r.Database.WithContext(ctx).Table("order_sagas").
+		Where("order_id = ?", orderID).
+		Take(&sg).Error
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadSagaSteps(ctx, r.Database, &sg); err != nil {
+		return nil, err
+	}
+	return &sg, nil
+}
+
+func (r *OrderRepository) loadSagaSteps(ctx context.Context, db *gorm.DB, sg *models.OrderSaga) error {
+	return db.WithContext(ctx).Table("order_saga_steps").
+		Where("saga_id = ?", sg.ID).
+		Order("id ASC").
+		Find(&sg.Steps).Error
+}
+
+// SaveOrderSagaTx saga 상태를 갱신하고 새 step(ID 0)은 추가, 기존 step은 상태만 갱신한다.
+func (r *OrderRepository) SaveOrderSagaTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga) error {
+	now := time.Now()
+	err := tx.WithContext(ctx).Table("order_sagas").Where("id = ?", sg.ID).Updates(map[string]interface{}{
+		"status":            sg.Status,
+		"current_step":      sg.CurrentStep,
+		"last_error":        sg.LastError,
+		"update_time":       now,
+		"recovery_attempts": 0,
+		"next_retry_at":     nil,
+	}).Error
+	if err != nil {
+		return err
+	}
+	for i := range sg.Steps {
+		step := &sg.Steps[i]
+		if step.ID == 0 {
+			step.SagaID = sg.ID
+			if err := tx.WithContext(ctx).Table("order_saga_steps").Create(step).Error; err != nil {
+				return err
+			}
+			continue
+		}
+		err := tx.WithContext(ctx).Table("order_saga_steps").Where("id = ?", step.ID).Updates(map[string]interface{}{
+			"status":      step.Status,
+			"detail":      step.Detail,
+			"update_time": now,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetStalledSagaOrderIDs cutoff 이후 갱신되지 않은 채 pending step이 남은 saga (재시작/실행 실패로 멈춘 saga).
+// 실행이 실패한 saga는 next_retry_at이 지날 때까지 제외해 배치 앞자리를 계속 차지하지 않게 한다.
+func (r *OrderRepository) GetStalledSagaOrderIDs(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	var orderIDs []int64
+	err := r.Database.WithContext(ctx).Table("order_sagas").
+		Select("order_sagas.order_id").
+		Where("order_sagas.status IN ?", []string{models.SagaStatusRunning, models.SagaStatusCompensating}).
+		Where("order_sagas.update_time < ?::timestamp", formatTimestamp(cutoff)).
+		Where("(order_sagas.next_retry_at IS NULL OR order_sagas.next_retry_at <= ?::timestamp)", formatTimestamp(time.Now())).
+		Where("EXISTS (SELECT 1 FROM order_saga_steps s WHERE s.saga_id = order_sagas.id AND s.status = ?)", models.SagaStepStatusPending).
+		Order("order_sagas.update_time ASC").
+		Limit(limit).
+		Pluck("order_sagas.order_id", &orderIDs).Error
+	return orderIDs, err
+}
+
+// DeferOrderSagaRecovery step 실행 트랜잭션이 롤백된 뒤 호출한다. 실패 횟수를 올리고
+// baseDelay * 2^(이전 실패 횟수)만큼(최대 maxDelay) 다음 recovery를 미룬다. update_time은 건드리지 않는다.
+func (r *OrderRepository) DeferOrderSagaRecovery(ctx context.Context, orderID int64, baseDelay, maxDelay time.Duration) error {
+	return r.Database.WithContext(ctx).Table("order_sagas").
+		Where("order_id = ?", orderID).
+		Updates(map[string]interface{}{
+			"recovery_attempts": gorm.Expr("recovery_attempts + 1"),
+			"next_retry_at": gorm.Expr("?::timestamp + make_interval(secs => LEAST(? * power(2, recovery_attempts), ?))",
+				formatTimestamp(time.Now()), baseDelay.Seconds(), maxDelay.Seconds()),
+		}).Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"orderfc/models"
+
+	"gorm.io/gorm"
+)
+
+// 검색 문서: 사용자/수령인(A) > 상품명·SKU(B) > 배송지(C) 가중치. 한국어 주소와 상품명이 섞여 있어
+// 형태소 분석 없이 'simple' 설정을 쓰고, 부분 일치는 질의 쪽 접두사 검색(:*)으로 처리한다.
+// 연락처는 하이픈 없이 입력해도 찾을 수 있도록 숫자만 남긴 값도 함께 넣는다.
+const orderSearchRefreshSQL = `
+UPDATE orders SET
+	search_document = concat_ws(' | ', d.who, NULLIF(d.products, ''), d.address),
+	search_vector = setweight(to_tsvector('simple', d.who), 'A') ||
+		setweight(to_tsvector('simple', d.products), 'B') ||
+		setweight(to_tsvector('simple', d.address), 'C')
+FROM (
+	SELECT o.id,
+		concat_ws(' ', o.user_id::text, NULLIF(o.shipping_recipient, ''), NULLIF(o.shipping_phone, ''),
+			NULLIF(regexp_replace(o.shipping_phone, '\D', '', 'g'), '')) AS who,
+		coalesce(string_agg(concat_ws(' ', NULLIF(oi.product_name, ''), NULLIF(oi.product_sku, '')), ' ' ORDER BY oi.id), '') AS products,
+		concat_ws(' ', NULLIF(o.shipping_line1, ''), NULLIF(o.shipping_line2, ''), NULLIF(o.shipping_city, ''),
+			NULLIF(o.shipping_postal_code, ''), NULLIF(o.shipping_country, ''), NULLIF(o.shipping_address, '')) AS address
+	FROM orders o
+	LEFT JOIN order_items oi ON oi.order_id = o.id
+	WHERE %s
+	GROUP BY o.id
+) d
+WHERE orders.id = d.id`
+
+// RefreshOrderSearchTx 주문 행과 order_items가 저장된 뒤 같은 트랜잭션에서 호출한다.
+func (r *OrderRepository) RefreshOrderSearchTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return tx.WithContext(ctx).Exec(fmt.Sprintf(orderSearchRefreshSQL, "o.id = ?"), orderID).Error
+}
+
+// BackfillOrderSearch 검색 문서가 없는 주문을 id 순으로 최대 limit건 채운다. 채운 주문은 다음 배치에서 제외된다.
+func (r *OrderRepository) BackfillOrderSearch(ctx context.Context, limit int) (int64, error) {
+	result := r.Database.WithContext(ctx).Exec(fmt.Sprintf(orderSearchRefreshSQL,
+		"o.id IN (SELECT id FROM orders WHERE search_vector IS NULL ORDER BY id LIMIT ?)"), limit)
+	return result.RowsAffected, result.Error
+}
+
+// 전문 검색은 순위순이라 keyset cursor 대신 offset 페이지네이션을 쓴다.
+// ts_headline은 비용이 커서 페이지에 포함된 행에만 계산한다. 검색 문서는 고객이 입력한 값이라 HTML 이스케이프한 뒤
+// 발췌해 결과에서 원시 태그는 <mark>만 남긴다.
+const orderFullTextSearchSQL = `
+SELECT ranked.id AS order_id, ranked.user_id, ranked.status, ranked.amount, ranked.currency, ranked.create_time, ranked.rank,
+	ts_headline('simple',
+		replace(replace(replace(replace(replace(ranked.search_document, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;'),
+		ranked.query,
+		'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5, MaxFragments=3, FragmentDelimiter=" … "') AS headline
+FROM (
+	SELECT o.id, o.user_id, o.status, o.amount, o.currency, o.create_time, o.search_document, q.query,
+		ts_rank(o.search_vector, q.query) AS rank
+	FROM orders o, to_tsquery('simple', @query) AS q(query)
+	WHERE o.search_vector @@ q.query AND (CAST(@user_id AS bigint) = 0 OR o.user_id = @user_id)
+	ORDER BY rank DESC, o.id DESC
+	LIMIT @limit OFFSET @offset
+) ranked
+ORDER BY ranked.rank DESC, ranked.id DESC`
+
+// FullTextSearchOrders tsQuery는 to_tsquery 문법으로 이미 만들어진 질의다.
+func (r *OrderRepository) FullTextSearchOrders(ctx context.Context, tsQuery string, params models.OrderSearchParam) ([]models.OrderSearchResult, error) {
+	var results []models.OrderSearchResult
+	err := r.Database.WithContext(ctx).Raw(orderFullTextSearchSQL, map[string]interface{}{
+		"query":   tsQuery,
+		"user_id": params.UserID,
+		"limit":   params.Limit,
+		"offset":  params.Offset,
+	}).Scan(&results).Error
+	return results, err
+}
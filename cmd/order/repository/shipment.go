@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UpsertShipmentTx 주문당 배송 레코드는 하나라 재출고(운송장 정정) 시 덮어쓴다.
+func (r *OrderRepository) UpsertShipmentTx(ctx context.Context, tx *gorm.DB, shipment *models.Shipment) error {
+	return tx.WithContext(ctx).Table("shipments").
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "order_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"carrier":         shipment.Carrier,
+				"tracking_number": shipment.TrackingNumber,
+				"shipped_at":      shipment.ShippedAt,
+				"update_time":     time.Now(),
+			}),
+		}).
+		Create(shipment).Error
+}
+
+func (r *OrderRepository) MarkShipmentDeliveredTx(ctx context.Context, tx *gorm.DB, orderID int64, deliveredAt time.Time) error {
+	return tx.WithContext(ctx).Table("shipments").
+		Where("order_id = ?", orderID).
+		Updates(map[string]interface{}{
+			"delivered_at": deliveredAt,
+			"update_time":  time.Now(),
+		}).Error
+}
+
+func (r *OrderRepository) GetShipmentByOrderID(ctx context.Context, orderID int64) (*models.Shipment, error) {
+	var shipment models.Shipment
+	err := r.Database.WithContext(ctx).Table("shipments").
+		Where("order_id = ?", orderID).
+		Take(&shipment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrderStore OrderService가 쓰는 저장소 기능. 운영 구현은 *OrderRepository이며, 테스트는 필요한 메서드만 가진 가짜로 대체한다.
+type OrderStore interface {
+	WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error
+
+	// 주문
+	InsertOrderTx(ctx context.Context, tx *gorm.DB, order *models.Order) error
+	InsertOrderDetailTx(ctx context.Context, tx *gorm.DB, orderDetail *models.OrderDetail) error
+	InsertOrderItemsTx(ctx context.Context, tx *gorm.DB, items []models.OrderItem) error
+	GetOrderItemsByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderItem, error)
+	GetOrderItemsByOrderIDsTx(ctx context.Context, tx *gorm.DB, orderIDs []int64) (map[int64][]models.OrderItem, error)
+	InsertOrderTaxLinesTx(ctx context.Context, tx *gorm.DB, taxLines []models.OrderTaxLine) error
+	GetOrdersWithoutItems(ctx context.Context, afterID int64, limit int) ([]models.Order, error)
+	InsertOrderOutboxEventsTx(ctx context.Context, tx *gorm.DB, events []models.OrderOutboxEvent) error
+	GetOrderHistoryByUserId(ctx context.Context, params models.OrderHistoryParam) ([]models.OrderHistoryResponse, error)
+	SearchOrders(ctx context.Context, params models.OrderHistoryParam) ([]models.OrderHistoryResponse, error)
+	GetOrderHistoryByOrderID(ctx context.Context, orderID int64) (*models.OrderHistoryResponse, error)
+	GetOrderForUpdateTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.Order, error)
+	UpdateOrderStatusTx(ctx context.Context, tx *gorm.DB, orderID int64, status int) error
+	AppendOrderHistoryTx(ctx context.Context, tx *gorm.DB, orderDetailID int64, entry models.StatusHistory) error
+	GetOrderInfoByOrderID(ctx context.Context, orderID int64) (*models.Order, error)
+	GetOrderDetailByID(ctx context.Context, orderDetailID int64) (*models.OrderDetail, error)
+	GetOrderDetailByIDTx(ctx context.Context, tx *gorm.DB, orderDetailID int64) (*models.OrderDetail, error)
+	GetDailySalesReport(ctx context.Context, days int) ([]models.DailySalesReport, error)
+	MigrateLegacyOrderCurrency(ctx context.Context, baseCurrency string) error
+	ClaimExpiredOrdersTx(ctx context.Context, tx *gorm.DB, statuses []int, cutoff time.Time, limit int) ([]models.Order, error)
+	DeferOrderExpiryTx(ctx context.Context, tx *gorm.DB, orderID int64, retryAt time.Time) error
+
+	// 멱등성
+	ReserveIdempotencyToken(ctx context.Context, idempotencyToken, requestHash string) (*models.OrderRequestLog, bool, error)
+	MarkIdempotencyTokenSucceededTx(ctx context.Context, tx *gorm.DB, idempotencyToken string, orderID int64) error
+	MarkIdempotencyTokenFailed(ctx context.Context, idempotencyToken string, processErr error) error
+	ReleaseIdempotencyToken(ctx context.Context, idempotencyToken string) error
+	CheckIdempotencyToken(ctx context.Context, idempotencyToken string) (bool, error)
+	SaveIdempotencyToken(ctx context.Context, idempotencyToken string) error
+
+	// 주문 변경
+	DeleteOrderItemsTx(ctx context.Context, tx *gorm.DB, orderID int64) error
+	DeleteOrderTaxLinesTx(ctx context.Context, tx *gorm.DB, orderID int64) error
+	UpdateAmendedOrderTx(ctx context.Context, tx *gorm.DB, order *models.Order) error
+	UpdateOrderDetailProductsTx(ctx context.Context, tx *gorm.DB, orderDetailID int64, products string) error
+
+	// 프로모션
+	GetPromotionsByCodes(ctx context.Context, codes []string) ([]models.Promotion, error)
+	GetAutoApplyPromotions(ctx context.Context, now time.Time) ([]models.Promotion, error)
+	CountUserRedemptions(ctx context.Context, userID int64, promotionIDs []int64, excludeOrderID int64) (map[int64]int, error)
+	GetPromotionForUpdateTx(ctx context.Context, tx *gorm.DB, promotionID int64) (*models.Promotion, error)
+	CountUserRedemptionsTx(ctx context.Context, tx *gorm.DB, promotionID, userID int64) (int64, error)
+	InsertCouponRedemptionTx(ctx context.Context, tx *gorm.DB, redemption *models.CouponRedemption) error
+	ReleaseCouponRedemptionsTx(ctx context.Context, tx *gorm.DB, orderID int64) error
+	InsertOrderDiscountsTx(ctx context.Context, tx *gorm.DB, discounts []models.OrderDiscount) error
+	DeleteOrderDiscountsTx(ctx context.Context, tx *gorm.DB, orderID int64) error
+	GetOrderDiscountsByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderDiscount, error)
+
+	// saga
+	InsertOrderSagaTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga) error
+	GetOrderSagaForUpdateTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.OrderSaga, error)
+	GetOrderSaga(ctx context.Context, orderID int64) (*models.OrderSaga, error)
+	SaveOrderSagaTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga) error
+	GetStalledSagaOrderIDs(ctx context.Context, cutoff time.Time, limit int) ([]int64, error)
+	DeferOrderSagaRecovery(ctx context.Context, orderID int64, baseDelay, maxDelay time.Duration) error
+
+	// 배송
+	UpsertShipmentTx(ctx context.Context, tx *gorm.DB, shipment *models.Shipment) error
+	MarkShipmentDeliveredTx(ctx context.Context, tx *gorm.DB, orderID int64, deliveredAt time.Time) error
+
+	// 감사 로그
+	EnsureOrderAuditLogAppendOnly(ctx context.Context) error
+	InsertOrderAuditLogTx(ctx context.Context, tx *gorm.DB, entry *models.OrderAuditLog) error
+	GetOrderAuditLogs(ctx context.Context, orderID int64) ([]models.OrderAuditLog, error)
+
+	// 검색
+	RefreshOrderSearchTx(ctx context.Context, tx *gorm.DB, orderID int64) error
+	BackfillOrderSearch(ctx context.Context, limit int) (int64, error)
+	FullTextSearchOrders(ctx context.Context, tsQuery string, params models.OrderSearchParam) ([]models.OrderSearchResult, error)
+
+	// 장바구니
+	GetCart(ctx context.Context, userID int64) (*models.Cart, error)
+	UpdateCart(ctx context.Context, userID int64, ttl time.Duration, fn func(cart *models.Cart) error) (*models.Cart, error)
+	DeleteCart(ctx context.Context, userID int64) error
+
+	// 상품 (productfc + Redis 캐시)
+	GetProductInfo(ctx context.Context, productID int64) (models.Product, error)
+	RefreshProductInfo(ctx context.Context, productID int64) (models.Product, error)
+	InvalidateProductCache(ctx context.Context, productIDs ...int64)
+	GetProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error)
+	RefreshProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error)
+}
+
+var _ OrderStore = (*OrderRepository)(nil)
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"orderfc/infrastructure/constant"
+	"orderfc/kafka"
+	"orderfc/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// OrderAmendment 결제 전 주문 변경 내용. Order에는 다시 계산된 금액, 결제 수단, 배송지가 반영되어 있다.
+type OrderAmendment struct {
+	Order      *models.Order
+	Products   string // order_details.products JSON
+	Components OrderComponents
+	Reason     string // 상태 이력에 남길 변경 항목
+}
+
+// AmendOrder 주문 라인/할인/세금을 교체하고 수량 차이만큼 stock.updated / stock.rollback을 outbox에 적재한다.
+// 가격 계산 이후 주문이 바뀌었으면(update_time 불일치) ErrOrderModified를 반환한다.
+func (s *OrderService) AmendOrder(ctx context.Context, amendment OrderAmendment) error {
+	order := amendment.Order
+	return s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		current, err := s.OrderRepo.GetOrderForUpdateTx(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if current.Status != constant.OrderStatusCreated {
+			return ErrOrderNotAmendable
+		}
+		if !current.UpdateTime.Equal(order.UpdateTime) {
+			return ErrOrderModified
+		}
+
+		// 행 잠금 이후 읽으므로 직전 변경까지 반영된 수량이다.
+		previous, err := s.GetOrderProductsTx(ctx, tx, current)
+		if err != nil {
+			return err
+		}
+
+		if err := s.OrderRepo.ReleaseCouponRedemptionsTx(ctx, tx, order.ID); err != nil {
+			return err
+		}
+		if err := s.OrderRepo.DeleteOrderDiscountsTx(ctx, tx, order.ID); err != nil {
+			return err
+		}
+		if err := s.OrderRepo.DeleteOrderItemsTx(ctx, tx, order.ID); err != nil {
+			return err
+		}
+		if err := s.OrderRepo.DeleteOrderTaxLinesTx(ctx, tx, order.ID); err != nil {
+			return err
+		}
+
+		if err := s.OrderRepo.UpdateAmendedOrderTx(ctx, tx, order); err != nil {
+			return err
+		}
+		if err := s.OrderRepo.UpdateOrderDetailProductsTx(ctx, tx, current.OrderDetailID, amendment.Products); err != nil {
+			return err
+		}
+
+		components := amendment.Components
+		for i := range components.Items {
+			components.Items[i].OrderID = order.ID
+		}
+		if err := s.OrderRepo.InsertOrderItemsTx(ctx, tx, components.Items); err != nil {
+			return err
+		}
+		if err := s.OrderRepo.RefreshOrderSearchTx(ctx, tx, order.ID); err != nil {
+			return err
+		}
+		if err := s.redeemDiscountsTx(ctx, tx, current.UserID, order.ID, components.Discounts); err != nil {
+			return err
+		}
+		for i := range components.TaxLines {
+			components.TaxLines[i].OrderID = order.ID
+		}
+		if err := s.OrderRepo.InsertOrderTaxLinesTx(ctx, tx, components.TaxLines); err != nil {
+			return err
+		}
+
+		err = s.OrderRepo.AppendOrderHistoryTx(ctx, tx, current.OrderDetailID, models.StatusHistory{
+			Status:    current.Status,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Actor:     constant.OrderActorUser,
+			Reason:    amendment.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		err = s.recordAuditTx(ctx, tx, models.OrderAuditLog{
+			OrderID:    order.ID,
+			Action:     models.OrderAuditActionAmended,
+			FromStatus: statusPtr(current.Status),
+			ToStatus:   statusPtr(current.Status),
+			Reason:     amendment.Reason,
+		}, constant.OrderActorUser)
+		if err != nil {
+			return err
+		}
+
+		events, err := stockDeltaOutboxEvents(current, previous, components.Items)
+		if err != nil {
+			return err
+		}
+		return s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, events)
+	})
+}
+
+// stockDeltaOutboxEvents 상품별 수량 차이를 계산해 늘어난 수량은 stock.updated, 줄어든 수량은 stock.rollback으로 보낸다.
+func stockDeltaOutboxEvents(order *models.Order, previous []models.CheckoutItem, items []models.OrderItem) ([]models.OrderOutboxEvent, error) {
+	delta := make(map[int64]int)
+	for _, item := range previous {
+		delta[item.ProductID] -= item.Quantity
+	}
+	for _, item := range items {
+		delta[item.ProductID] += item.Quantity
+	}
+	productIDs := make([]int64, 0, len(delta))
+	for productID := range delta {
+		productIDs = append(productIDs, productID)
+	}
+	sort.Slice(productIDs, func(i, j int) bool { return productIDs[i] < productIDs[j] })
+
+	var reserve, release []models.ProductItem
+	for _, productID := range productIDs {
+		switch qty := delta[productID]; {
+		case qty > 0:
+			reserve = append(reserve, models.ProductItem{ProductID: productID, Quantity: qty})
+		case qty < 0:
+			release = append(release, models.ProductItem{ProductID: productID, Quantity: -qty})
+		}
+	}
+
+	var events []models.OrderOutboxEvent
+	if len(reserve) > 0 {
+		event, err := kafka.NewStockUpdatedOutboxEvent(order.ID, order.UserID, reserve)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	if len(release) > 0 {
+		event, err := kafka.NewStockRollbackOutboxEvent(order.ID, order.UserID, release)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (s *OrderService) GetOrderDiscounts(ctx context.Context, orderID int64) ([]models.OrderDiscount, error) {
+	discountsByOrder, err := s.OrderRepo.GetOrderDiscountsByOrderIDs(ctx, []int64{orderID})
+	if err != nil {
+		return nil, err
+	}
+	return discountsByOrder[orderID], nil
+}
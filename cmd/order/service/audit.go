@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"orderfc/infrastructure/audit"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+
+	"gorm.io/gorm"
+)
+
+func (s *OrderService) EnsureOrderAuditLogAppendOnly(ctx context.Context) error {
+	return s.OrderRepo.EnsureOrderAuditLogAppendOnly(ctx)
+}
+
+func (s *OrderService) GetOrderAuditLogs(ctx context.Context, orderID int64) ([]models.OrderAuditLog, error) {
+	return s.OrderRepo.GetOrderAuditLogs(ctx, orderID)
+}
+
+// recordAuditTx 주문 변경과 같은 트랜잭션에 감사 로그를 남긴다. 행위자와 출처는 context에서 가져오고,
+// context에 행위자가 없으면 상태 이력의 actor로 대신한다.
+func (s *OrderService) recordAuditTx(ctx context.Context, tx *gorm.DB, entry models.OrderAuditLog, fallbackActor string) error {
+	if actor, ok := audit.ActorFrom(ctx); ok {
+		entry.ActorType = actor.Type
+		entry.ActorID = actor.ID
+	} else {
+		entry.ActorType = auditActorType(fallbackActor)
+		if entry.ActorType == audit.ActorJob {
+			entry.ActorID = fallbackActor
+		}
+	}
+	if source, ok := audit.SourceFrom(ctx); ok {
+		entry.SourceType = source.Type
+		entry.SourceRef = source.Ref
+	}
+	return s.OrderRepo.InsertOrderAuditLogTx(ctx, tx, &entry)
+}
+
+func auditActorType(actor string) string {
+	switch actor {
+	case constant.OrderActorUser:
+		return audit.ActorUser
+	case constant.OrderActorAdmin:
+		return audit.ActorAdmin
+	default:
+		// system(만료 처리)과 saga는 백그라운드 처리다.
+		return audit.ActorJob
+	}
+}
+
+func statusPtr(status int) *int {
+	return &status
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"orderfc/models"
+	"time"
+)
+
+func (s *OrderService) GetCart(ctx context.Context, userID int64) (*models.Cart, error) {
+	return s.OrderRepo.GetCart(ctx, userID)
+}
+
+func (s *OrderService) UpdateCart(ctx context.Context, userID int64, ttl time.Duration, fn func(cart *models.Cart) error) (*models.Cart, error) {
+	return s.OrderRepo.UpdateCart(ctx, userID, ttl, fn)
+}
+
+func (s *OrderService) DeleteCart(ctx context.Context, userID int64) error {
+	return s.OrderRepo.DeleteCart(ctx, userID)
+}
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"orderfc/infrastructure/constant"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrOrderNotAmendable       = errors.New("order can only be amended before payment")
+	ErrOrderModified           = errors.New("order was modified concurrently")
+	ErrLatePaymentRefunded     = errors.New("payment succeeded after the order was cancelled; refund requested")
+	ErrAutoPromotionExhausted  = errors.New("auto-applied promotion ran out before the order was saved")
+)
+
+// StatusTransitionError 상태 머신이 허용하지 않는 전이를 요청했을 때 반환된다.
+// errors.Is(err, ErrInvalidStatusTransition)으로 판별할 수 있다.
+type StatusTransitionError struct {
+	OrderID int64
+	From    int
+	To      int
+}
+
+func (e *StatusTransitionError) Error() string {
+	return fmt.Sprintf("order %d cannot transition from %s to %s", e.OrderID, constant.OrderStatusMap[e.From], constant.OrderStatusMap[e.To])
+}
+
+func (e *StatusTransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
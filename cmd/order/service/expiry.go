@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const PaymentTimeoutReason = "payment_timeout"
+
+const (
+	expiryRetryBaseDelay = time.Minute
+	expiryRetryMaxDelay  = time.Hour
+)
+
+// expiryRetryDelay 실패 횟수에 따라 지수적으로 늘리되 expiryRetryMaxDelay를 넘지 않는다.
+func expiryRetryDelay(attempts int) time.Duration {
+	delay := expiryRetryBaseDelay
+	for i := 0; i < attempts && delay < expiryRetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > expiryRetryMaxDelay {
+		delay = expiryRetryMaxDelay
+	}
+	return delay
+}
+
+// ExpireUnpaidOrders cutoff 이전에 생성되어 아직 결제되지 않은 주문(created, processing)을 취소하고 stock.rollback을 outbox에 적재한다.
+// 주문별로 savepoint를 써서 한 건이 실패해도 나머지는 커밋된다. 실패한 주문은 backoff 후 다시 claim된다.
+// 취소된 주문 수를 반환한다.
+func (s *OrderService) ExpireUnpaidOrders(ctx context.Context, cutoff time.Time, limit int) (int, error) {
+	expired := 0
+	err := s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		orders, err := s.OrderRepo.ClaimExpiredOrdersTx(ctx, tx, []int{constant.OrderStatusCreated, constant.OrderStatusProcessing}, cutoff, limit)
+		if err != nil {
+			return err
+		}
+		for i := range orders {
+			order := &orders[i]
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				return s.expireOrderTx(ctx, tx, order)
+			})
+			if err != nil {
+				retryAt := time.Now().Add(expiryRetryDelay(order.ExpiryAttempts))
+				log.Logger.Error().Err(err).Int64("order_id", order.ID).Int("attempts", order.ExpiryAttempts+1).Time("retry_at", retryAt).Msg("Failed to expire unpaid order")
+				if err := s.OrderRepo.DeferOrderExpiryTx(ctx, tx, order.ID, retryAt); err != nil {
+					return err
+				}
+				continue
+			}
+			expired++
+		}
+		return nil
+	})
+	return expired, err
+}
+
+func (s *OrderService) expireOrderTx(ctx context.Context, tx *gorm.DB, order *models.Order) error {
+	if _, err := s.TransitionOrderStatusTx(ctx, tx, order.ID, constant.OrderStatusCancelled, constant.OrderActorSystem, PaymentTimeoutReason); err != nil {
+		return err
+	}
+	rollbackEvents, err := s.ReservedStockRollbackEventsTx(ctx, tx, order)
+	if err != nil {
+		return err
+	}
+	return s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, rollbackEvents)
+}
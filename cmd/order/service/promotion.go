@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"orderfc/models"
+	"orderfc/promotion"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+func (s *OrderService) GetPromotionsByCodes(ctx context.Context, codes []string) ([]models.Promotion, error) {
+	return s.OrderRepo.GetPromotionsByCodes(ctx, codes)
+}
+
+func (s *OrderService) GetAutoApplyPromotions(ctx context.Context, now time.Time) ([]models.Promotion, error) {
+	return s.OrderRepo.GetAutoApplyPromotions(ctx, now)
+}
+
+func (s *OrderService) CountUserRedemptions(ctx context.Context, userID int64, promotionIDs []int64, excludeOrderID int64) (map[int64]int, error) {
+	return s.OrderRepo.CountUserRedemptions(ctx, userID, promotionIDs, excludeOrderID)
+}
+
+// redeemDiscountsTx 프로모션 행을 잠근 상태에서 사용 한도를 다시 확인하고 사용 기록과 할인 내역을 저장한다.
+// 평가 이후 다른 주문이 먼저 한도를 소진했다면 트랜잭션이 롤백된다. 직접 입력한 쿠폰은 ErrUsageLimitReached,
+// 자동 적용 프로모션은 ErrAutoPromotionExhausted를 반환해 호출자가 그 할인 없이 다시 가격을 계산하게 한다.
+func (s *OrderService) redeemDiscountsTx(ctx context.Context, tx *gorm.DB, userID, orderID int64, discounts []models.OrderDiscount) error {
+	for i := range discounts {
+		p, err := s.OrderRepo.GetPromotionForUpdateTx(ctx, tx, discounts[i].PromotionID)
+		if err != nil {
+			return err
+		}
+		if p.MaxRedemptions > 0 && p.RedeemedCount >= p.MaxRedemptions {
+			return usageLimitError(p)
+		}
+		if p.PerUserLimit > 0 {
+			used, err := s.OrderRepo.CountUserRedemptionsTx(ctx, tx, p.ID, userID)
+			if err != nil {
+				return err
+			}
+			if used >= int64(p.PerUserLimit) {
+				return usageLimitError(p)
+			}
+		}
+
+		err = s.OrderRepo.InsertCouponRedemptionTx(ctx, tx, &models.CouponRedemption{
+			PromotionID: p.ID,
+			UserID:      userID,
+			OrderID:     orderID,
+			Status:      models.CouponRedemptionStatusActive,
+		})
+		if err != nil {
+			return err
+		}
+		discounts[i].OrderID = orderID
+	}
+	return s.OrderRepo.InsertOrderDiscountsTx(ctx, tx, discounts)
+}
+
+func usageLimitError(p *models.Promotion) error {
+	if p.AutoApply {
+		return fmt.Errorf("%w: %s", ErrAutoPromotionExhausted, p.Code)
+	}
+	return fmt.Errorf("%w: %s", promotion.ErrUsageLimitReached, p.Code)
+}
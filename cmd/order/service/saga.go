@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/log"
+	"orderfc/kafka"
+	"orderfc/models"
+	"orderfc/saga"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// StartSagaTx 체크아웃 트랜잭션에서 주문과 함께 saga를 생성한다.
+func (s *OrderService) StartSagaTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return s.OrderRepo.InsertOrderSagaTx(ctx, tx, saga.Start(orderID))
+}
+
+// AdvanceSaga 응답 이벤트를 saga에 기록한 뒤 pending step을 실행한다.
+// 기록과 실행은 별도 트랜잭션이라 실행이 실패해도 이벤트는 남고, recovery worker가 이어서 실행한다.
+func (s *OrderService) AdvanceSaga(ctx context.Context, orderID int64, event saga.Event) error {
+	err := s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		sg, err := s.getOrStartSagaTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if err := saga.Apply(sg, event); err != nil {
+			return err
+		}
+		return s.OrderRepo.SaveOrderSagaTx(ctx, tx, sg)
+	})
+	if err != nil {
+		return err
+	}
+	return s.RunSagaSteps(ctx, orderID)
+}
+
+// getOrStartSagaTx saga 도입 이전에 생성된 주문은 초기 상태로 saga를 만들어 이어간다. 이미 종료된 주문은 제외한다.
+func (s *OrderService) getOrStartSagaTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.OrderSaga, error) {
+	sg, err := s.OrderRepo.GetOrderSagaForUpdateTx(ctx, tx, orderID)
+	if err == nil {
+		return sg, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	order, err := s.OrderRepo.GetOrderForUpdateTx(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != constant.OrderStatusCreated && order.Status != constant.OrderStatusProcessing {
+		return nil, saga.ErrSagaFinished
+	}
+	if err := s.StartSagaTx(ctx, tx, orderID); err != nil {
+		return nil, err
+	}
+	return s.OrderRepo.GetOrderSagaForUpdateTx(ctx, tx, orderID)
+}
+
+const (
+	sagaRetryBaseDelay = 30 * time.Second
+	sagaRetryMaxDelay  = 30 * time.Minute
+)
+
+// RunSagaSteps saga를 잠그고 pending step을 생성 순서대로 실행한다. 여러 replica가 동시에 호출해도 한 번만 실행된다.
+// 실행이 실패하면 트랜잭션이 롤백되므로 재시도 backoff는 별도로 기록한다.
+func (s *OrderService) RunSagaSteps(ctx context.Context, orderID int64) error {
+	err := s.runSagaSteps(ctx, orderID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		if deferErr := s.OrderRepo.DeferOrderSagaRecovery(ctx, orderID, sagaRetryBaseDelay, sagaRetryMaxDelay); deferErr != nil {
+			log.Logger.Error().Err(deferErr).Int64("order_id", orderID).Msg("Failed to record saga retry backoff")
+		}
+	}
+	return err
+}
+
+func (s *OrderService) runSagaSteps(ctx context.Context, orderID int64) error {
+	return s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		sg, err := s.OrderRepo.GetOrderSagaForUpdateTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		pending := saga.PendingSteps(sg)
+		if len(pending) == 0 {
+			return nil
+		}
+		for _, step := range pending {
+			if err := s.executeSagaStepTx(ctx, tx, sg, step); err != nil {
+				return err
+			}
+			sg.CurrentStep = step.Step
+			if step.Status == models.SagaStepStatusFailed {
+				break
+			}
+		}
+		saga.Settle(sg)
+		return s.OrderRepo.SaveOrderSagaTx(ctx, tx, sg)
+	})
+}
+
+// executeSagaStepTx step을 실행하고 결과를 step 상태에 반영한다. 재시도해도 결과가 같은 실패(상태 전이 불가)는
+// step 실패로 기록하고, 그 밖의 에러는 반환해 트랜잭션을 롤백한다 (다음 recovery에서 재시도).
+func (s *OrderService) executeSagaStepTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga, step *models.OrderSagaStep) error {
+	switch step.Step {
+	case models.SagaStepRequestPayment:
+		order, err := s.TransitionOrderStatusTx(ctx, tx, sg.OrderID, constant.OrderStatusProcessing, constant.OrderActorSaga, "stock_reserved")
+		if err != nil {
+			if errors.Is(err, ErrInvalidStatusTransition) {
+				step.Status = models.SagaStepStatusFailed
+				step.Detail = err.Error()
+				log.Logger.Error().Err(err).Int64("order_id", sg.OrderID).Msg("Saga could not move order to processing")
+				return nil
+			}
+			return err
+		}
+		paymentRequestedEvent, err := kafka.NewPaymentRequestedOutboxEvent(order)
+		if err != nil {
+			return err
+		}
+		if err := s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, []models.OrderOutboxEvent{paymentRequestedEvent}); err != nil {
+			return err
+		}
+		// 커맨드만 발행했고 결과는 payment.success / payment.failed로 받는다.
+		step.Status = models.SagaStepStatusWaiting
+		return nil
+
+	case models.SagaStepConfirmOrder:
+		_, err := s.TransitionOrderStatusTx(ctx, tx, sg.OrderID, constant.OrderStatusCompleted, constant.OrderActorSaga, "payment_success")
+		if err != nil {
+			if errors.Is(err, ErrInvalidStatusTransition) {
+				// 결제 완료 전에 주문이 취소된 경우. 환불은 결제 서비스 수동 처리 대상.
+				step.Status = models.SagaStepStatusFailed
+				step.Detail = err.Error()
+				sg.LastError = err.Error()
+				log.Logger.Error().Err(err).Int64("order_id", sg.OrderID).Msg("Saga could not confirm paid order")
+				return nil
+			}
+			return err
+		}
+
+	case models.SagaStepCancelOrder:
+		_, err := s.TransitionOrderStatusTx(ctx, tx, sg.OrderID, constant.OrderStatusCancelled, constant.OrderActorSaga, sg.LastError)
+		if err != nil {
+			var transitionErr *StatusTransitionError
+			if errors.As(err, &transitionErr) && transitionErr.From == constant.OrderStatusCancelled {
+				step.Detail = "already cancelled"
+				break
+			}
+			if errors.Is(err, ErrInvalidStatusTransition) {
+				step.Status = models.SagaStepStatusFailed
+				step.Detail = err.Error()
+				log.Logger.Error().Err(err).Int64("order_id", sg.OrderID).Msg("Saga could not cancel order")
+				return nil
+			}
+			return err
+		}
+
+	case models.SagaStepReleaseStock:
+		order, err := s.OrderRepo.GetOrderForUpdateTx(ctx, tx, sg.OrderID)
+		if err != nil {
+			return err
+		}
+		rollbackEvent, err := s.StockRollbackOutboxEventTx(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+		if err := s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, []models.OrderOutboxEvent{rollbackEvent}); err != nil {
+			return err
+		}
+
+	case models.SagaStepRefundPayment:
+		order, err := s.OrderRepo.GetOrderForUpdateTx(ctx, tx, sg.OrderID)
+		if err != nil {
+			return err
+		}
+		refundEvent, err := kafka.NewPaymentRefundRequestedOutboxEvent(order, "paid after "+sg.LastError)
+		if err != nil {
+			return err
+		}
+		if err := s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, []models.OrderOutboxEvent{refundEvent}); err != nil {
+			return err
+		}
+
+	default:
+		step.Status = models.SagaStepStatusFailed
+		step.Detail = "unknown step"
+		return nil
+	}
+
+	step.Status = models.SagaStepStatusSucceeded
+	return nil
+}
+
+// abortSagaTx saga 밖에서 주문이 취소/실패 처리되면 진행 중인 saga를 닫는다. saga가 없거나 이미 보상 중이면 무시한다.
+func (s *OrderService) abortSagaTx(ctx context.Context, tx *gorm.DB, orderID int64, reason string) error {
+	sg, err := s.OrderRepo.GetOrderSagaForUpdateTx(ctx, tx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if sg.Status != models.SagaStatusRunning {
+		return nil
+	}
+	saga.Abort(sg, reason)
+	return s.OrderRepo.SaveOrderSagaTx(ctx, tx, sg)
+}
+
+// ReservedStockRollbackEventsTx 취소로 되돌릴 예약 재고가 있을 때만 stock.rollback을 만든다. saga의 reserve_stock이
+// 아직 성공하지 않았으면 productfc가 재고를 잡지 않은 것이라 비워 두고, 늦게 도착한 stock.reserved가 release_stock으로
+// 해제한다. saga가 없는 이전 주문은 예약된 것으로 본다. 주문 상태 전이(saga 중단) 이후 같은 트랜잭션에서 호출한다.
+func (s *OrderService) ReservedStockRollbackEventsTx(ctx context.Context, tx *gorm.DB, order *models.Order) ([]models.OrderOutboxEvent, error) {
+	sg, err := s.OrderRepo.GetOrderSagaForUpdateTx(ctx, tx, order.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil && !saga.StockReserved(sg) {
+		return nil, nil
+	}
+	rollbackEvent, err := s.StockRollbackOutboxEventTx(ctx, tx, order)
+	if err != nil {
+		return nil, err
+	}
+	return []models.OrderOutboxEvent{rollbackEvent}, nil
+}
+
+// HandlePaymentSucceeded 결제 완료를 saga에 반영한다. 고객 취소/만료/관리자 취소로 saga가 이미 보상된 뒤라면
+// 결제만 남은 상태이므로 환불 요청을 발행하고 ErrLatePaymentRefunded를 반환한다. 같은 결제 완료의 중복 수신은
+// 환불을 다시 요청하지 않고 saga.ErrSagaFinished를 그대로 반환한다.
+func (s *OrderService) HandlePaymentSucceeded(ctx context.Context, orderID int64) error {
+	event := saga.Event{Type: saga.EventPaymentSucceeded, Reason: "payment_success"}
+	err := s.AdvanceSaga(ctx, orderID, event)
+	if !errors.Is(err, saga.ErrSagaFinished) && !errors.Is(err, saga.ErrUnexpectedEvent) {
+		return err
+	}
+	compensated, lateErr := s.compensateLateEvent(ctx, orderID, event)
+	if lateErr != nil {
+		return lateErr
+	}
+	if !compensated {
+		return err
+	}
+	return fmt.Errorf("%w: order %d", ErrLatePaymentRefunded, orderID)
+}
+
+// compensateLateEvent saga.ApplyLate로 보상 step을 추가했으면 바로 실행한다. 실행이 실패해도 step은 남아 recovery worker가 이어서 실행한다.
+func (s *OrderService) compensateLateEvent(ctx context.Context, orderID int64, event saga.Event) (bool, error) {
+	var compensated bool
+	err := s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		sg, err := s.OrderRepo.GetOrderSagaForUpdateTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if compensated = saga.ApplyLate(sg, event); !compensated {
+			return nil
+		}
+		return s.OrderRepo.SaveOrderSagaTx(ctx, tx, sg)
+	})
+	if err != nil || !compensated {
+		return false, err
+	}
+	if err := s.RunSagaSteps(ctx, orderID); err != nil {
+		log.Logger.Error().Err(err).Int64("order_id", orderID).Str("event", event.Type).Msg("Failed to run late saga compensation - recovery worker will retry")
+	}
+	return true, nil
+}
+
+// HandleStockReserved 예약 금액이 order.created로 보낸 금액과 같으면 결제 단계로 진행하고, 다르면 예약을 해제하고 주문을 취소한다.
+// 결제 전 주문 변경은 stock.updated / stock.rollback으로 수량만 조정하므로 stock.reserved는 최초 금액을 싣고 온다.
+func (s *OrderService) HandleStockReserved(ctx context.Context, event models.StockReservationEvent) error {
+	order, err := s.OrderRepo.GetOrderInfoByOrderID(ctx, event.OrderID)
+	if err != nil {
+		return err
+	}
+	reserved, err := event.ReservedAmount(order.Currency)
+	if err != nil {
+		return fmt.Errorf("stock.reserved total_amount %q (schema v%d): %w", event.TotalAmount, event.SchemaVersion, err)
+	}
+	expected := order.ReservationAmount
+	if expected == 0 {
+		expected = order.Amount
+	}
+	if reserved != expected {
+		reason := fmt.Sprintf("amount_mismatch: reserved %d, order %d", reserved, expected)
+		log.Logger.Warn().Int64("order_id", order.ID).Int64("reserved_amount", int64(reserved)).Int64("order_amount", int64(expected)).Msg("Stock reservation amount does not match order")
+		return s.advanceStockReservation(ctx, order.ID, saga.Event{Type: saga.EventStockMismatch, Reason: reason})
+	}
+	return s.advanceStockReservation(ctx, order.ID, saga.Event{Type: saga.EventStockReserved, Reason: "stock_reserved"})
+}
+
+// advanceStockReservation 예약 응답 전에 취소된 주문이면 stock.rollback을 보내지 않았으므로, 늦게 도착한 예약을 release_stock으로 해제한다.
+func (s *OrderService) advanceStockReservation(ctx context.Context, orderID int64, event saga.Event) error {
+	err := s.AdvanceSaga(ctx, orderID, event)
+	if !errors.Is(err, saga.ErrSagaFinished) && !errors.Is(err, saga.ErrUnexpectedEvent) {
+		return err
+	}
+	compensated, lateErr := s.compensateLateEvent(ctx, orderID, event)
+	if lateErr != nil {
+		return lateErr
+	}
+	if !compensated {
+		return err
+	}
+	log.Logger.Warn().Int64("order_id", orderID).Str("event", event.Type).Msg("Stock reserved after the order was cancelled - releasing stock")
+	return nil
+}
+
+func (s *OrderService) GetOrderSaga(ctx context.Context, orderID int64) (*models.OrderSaga, error) {
+	return s.OrderRepo.GetOrderSaga(ctx, orderID)
+}
+
+func (s *OrderService) GetStalledSagaOrderIDs(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	return s.OrderRepo.GetStalledSagaOrderIDs(ctx, cutoff, limit)
+}
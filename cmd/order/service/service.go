@@ -3,16 +3,20 @@ package service
 import (
 	"context"
 	"orderfc/cmd/order/repository"
+	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/log"
+	"orderfc/kafka"
 	"orderfc/models"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type OrderService struct {
-	OrderRepo repository.OrderRepository
+	OrderRepo repository.OrderStore
 }
 
-func NewOrderService(orderRepo repository.OrderRepository) *OrderService {
+func NewOrderService(orderRepo repository.OrderStore) *OrderService {
 	return &OrderService{OrderRepo: orderRepo}
 }
 
@@ -28,6 +32,10 @@ func (s *OrderService) ReserveIdempotencyToken(ctx context.Context, idempotencyT
 	return s.OrderRepo.ReserveIdempotencyToken(ctx, idempotencyToken, requestHash)
 }
 
+func (s *OrderService) ReleaseIdempotencyToken(ctx context.Context, idempotencyToken string) error {
+	return s.OrderRepo.ReleaseIdempotencyToken(ctx, idempotencyToken)
+}
+
 func (s *OrderService) MarkIdempotencyTokenFailed(ctx context.Context, idempotencyToken string, processErr error) error {
 	return s.OrderRepo.MarkIdempotencyTokenFailed(ctx, idempotencyToken, processErr)
 }
@@ -72,10 +80,18 @@ func (s *OrderService) SaveOrderAndOrderDetailWithOutbox(
 	return orderId, nil
 }
 
+// OrderComponents 주문과 같은 트랜잭션에서 저장되는 하위 레코드.
+type OrderComponents struct {
+	Items     []models.OrderItem
+	Discounts []models.OrderDiscount
+	TaxLines  []models.OrderTaxLine
+}
+
 func (s *OrderService) SaveOrderAndOrderDetailWithOutboxAndIdempotency(
 	ctx context.Context,
 	order *models.Order,
 	orderDetail *models.OrderDetail,
+	components OrderComponents,
 	idempotencyToken string,
 	buildEvents func(orderID int64) ([]models.OrderOutboxEvent, error),
 ) (int64, error) {
@@ -91,6 +107,37 @@ func (s *OrderService) SaveOrderAndOrderDetailWithOutboxAndIdempotency(
 		}
 		orderId = order.ID
 
+		for i := range components.Items {
+			components.Items[i].OrderID = orderId
+		}
+		if err := s.OrderRepo.InsertOrderItemsTx(ctx, tx, components.Items); err != nil {
+			return err
+		}
+		if err := s.OrderRepo.RefreshOrderSearchTx(ctx, tx, orderId); err != nil {
+			return err
+		}
+		if err := s.redeemDiscountsTx(ctx, tx, order.UserID, orderId, components.Discounts); err != nil {
+			return err
+		}
+		for i := range components.TaxLines {
+			components.TaxLines[i].OrderID = orderId
+		}
+		if err := s.OrderRepo.InsertOrderTaxLinesTx(ctx, tx, components.TaxLines); err != nil {
+			return err
+		}
+		if err := s.StartSagaTx(ctx, tx, orderId); err != nil {
+			return err
+		}
+		err := s.recordAuditTx(ctx, tx, models.OrderAuditLog{
+			OrderID:  orderId,
+			Action:   models.OrderAuditActionCreated,
+			ToStatus: statusPtr(order.Status),
+			Reason:   "checkout",
+		}, constant.OrderActorUser)
+		if err != nil {
+			return err
+		}
+
 		if buildEvents != nil {
 			events, err := buildEvents(orderId)
 			if err != nil {
@@ -122,6 +169,31 @@ func (s *OrderService) GetOrderHistoryByUserId(ctx context.Context, params model
 	return results, nil
 }
 
+func (s *OrderService) SearchOrders(ctx context.Context, params models.OrderHistoryParam) ([]models.OrderHistoryResponse, error) {
+	return s.OrderRepo.SearchOrders(ctx, params)
+}
+
+func (s *OrderService) FullTextSearchOrders(ctx context.Context, tsQuery string, params models.OrderSearchParam) ([]models.OrderSearchResult, error) {
+	return s.OrderRepo.FullTextSearchOrders(ctx, tsQuery, params)
+}
+
+// BackfillOrderSearch 검색 문서가 없는 주문을 batchSize씩 채운다. 배치마다 별도 문장이라 행 잠금이 짧고,
+// 기동 중 중단돼도 다음 기동에서 남은 주문부터 이어서 채운다.
+func (s *OrderService) BackfillOrderSearch(ctx context.Context, batchSize int) (int64, error) {
+	var backfilled int64
+	for {
+		n, err := s.OrderRepo.BackfillOrderSearch(ctx, batchSize)
+		backfilled += n
+		if err != nil || n < int64(batchSize) {
+			return backfilled, err
+		}
+	}
+}
+
+func (s *OrderService) GetOrderHistoryByOrderID(ctx context.Context, orderID int64) (*models.OrderHistoryResponse, error) {
+	return s.OrderRepo.GetOrderHistoryByOrderID(ctx, orderID)
+}
+
 func (s *OrderService) GetProductInfo(ctx context.Context, productID int64) (models.Product, error) {
 	product, err := s.OrderRepo.GetProductInfo(ctx, productID)
 	if err != nil {
@@ -130,12 +202,160 @@ func (s *OrderService) GetProductInfo(ctx context.Context, productID int64) (mod
 	return product, nil
 }
 
-func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID int64, status int) error {
-	err := s.OrderRepo.UpdateOrderStatus(ctx, orderID, status)
-	if err != nil {
+// GetProductInfos 실패한 상품은 itemErrs에 상품 ID별로 담긴다.
+func (s *OrderService) GetProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error) {
+	return s.OrderRepo.GetProductInfos(ctx, productIDs)
+}
+
+func (s *OrderService) RefreshProductInfos(ctx context.Context, productIDs []int64) (map[int64]models.Product, map[int64]error) {
+	return s.OrderRepo.RefreshProductInfos(ctx, productIDs)
+}
+
+// RefreshProductInfo 캐시를 거치지 않고 productfc에서 다시 조회한다 (캐시도 갱신된다).
+func (s *OrderService) RefreshProductInfo(ctx context.Context, productID int64) (models.Product, error) {
+	return s.OrderRepo.RefreshProductInfo(ctx, productID)
+}
+
+func (s *OrderService) InvalidateProductCache(ctx context.Context, productIDs ...int64) {
+	s.OrderRepo.InvalidateProductCache(ctx, productIDs...)
+}
+
+// UpdateOrderStatus 상태 머신 검증 후 상태 변경과 이력 추가를 하나의 트랜잭션으로 처리한다.
+func (s *OrderService) UpdateOrderStatus(ctx context.Context, orderID int64, status int, actor, reason string) error {
+	return s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		_, err := s.TransitionOrderStatusTx(ctx, tx, orderID, status, actor, reason)
 		return err
+	})
+}
+
+// TransitionOrderStatusWithOutbox 상태 전이와 outbox 이벤트 적재를 하나의 트랜잭션으로 처리한다.
+func (s *OrderService) TransitionOrderStatusWithOutbox(
+	ctx context.Context,
+	orderID int64,
+	status int,
+	actor, reason string,
+	buildEvents func(tx *gorm.DB, order *models.Order) ([]models.OrderOutboxEvent, error),
+) error {
+	return s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		order, err := s.TransitionOrderStatusTx(ctx, tx, orderID, status, actor, reason)
+		if err != nil {
+			return err
+		}
+		if buildEvents == nil {
+			return nil
+		}
+		events, err := buildEvents(tx, order)
+		if err != nil {
+			return err
+		}
+		return s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, events)
+	})
+}
+
+// TransitionOrderStatusTx 주문 행을 잠근 뒤 전이를 검증하고, 허용되면 상태와 이력을 갱신한다.
+// 모든 전이는 같은 트랜잭션에서 order.status_changed outbox 이벤트를 남긴다.
+func (s *OrderService) TransitionOrderStatusTx(ctx context.Context, tx *gorm.DB, orderID int64, status int, actor, reason string) (*models.Order, error) {
+	order, err := s.OrderRepo.GetOrderForUpdateTx(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if !constant.CanTransitionOrderStatus(order.Status, status) {
+		return nil, &StatusTransitionError{OrderID: orderID, From: order.Status, To: status}
+	}
+	return s.applyOrderStatusTx(ctx, tx, order, status, actor, reason, models.OrderAuditActionStatusChanged)
+}
+
+// OverrideOrderStatus 관리자 상태 변경. force면 상태 머신 검증을 건너뛰지만 같은 상태로의 변경과 취소/실패 주문의 복구는
+// 거부한다 (쿠폰과 재고가 이미 반환됨). 쿠폰 반환/saga 중단/이력/outbox 이벤트는 일반 전이와 동일하게 처리하고,
+// 재고를 잡고 있던 주문을 취소/실패로 바꾸면 고객 취소와 같은 stock.rollback을 남긴다.
+func (s *OrderService) OverrideOrderStatus(ctx context.Context, orderID int64, status int, actor, reason string, force bool) (*models.Order, error) {
+	var updated *models.Order
+	err := s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		order, err := s.OrderRepo.GetOrderForUpdateTx(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		from := order.Status
+		auditAction := models.OrderAuditActionStatusChanged
+		if force {
+			if from == status || from == constant.OrderStatusCancelled || from == constant.OrderStatusFailed {
+				return &StatusTransitionError{OrderID: orderID, From: from, To: status}
+			}
+			auditAction = models.OrderAuditActionStatusOverridden
+		} else if !constant.CanTransitionOrderStatus(from, status) {
+			return &StatusTransitionError{OrderID: orderID, From: from, To: status}
+		}
+
+		updated, err = s.applyOrderStatusTx(ctx, tx, order, status, actor, reason, auditAction)
+		if err != nil {
+			return err
+		}
+		if !holdsStock(from) || (status != constant.OrderStatusCancelled && status != constant.OrderStatusFailed) {
+			return nil
+		}
+		rollbackEvents, err := s.ReservedStockRollbackEventsTx(ctx, tx, updated)
+		if err != nil {
+			return err
+		}
+		return s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, rollbackEvents)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// holdsStock 결제 완료 전 주문만 예약 재고를 잡고 있다. 완료 이후 주문의 재고는 출고 대상이라 되돌리지 않는다.
+func holdsStock(status int) bool {
+	return status == constant.OrderStatusCreated || status == constant.OrderStatusProcessing
+}
+
+func (s *OrderService) applyOrderStatusTx(ctx context.Context, tx *gorm.DB, order *models.Order, status int, actor, reason, auditAction string) (*models.Order, error) {
+	orderID := order.ID
+	if status == constant.OrderStatusCancelled || status == constant.OrderStatusFailed {
+		if err := s.OrderRepo.ReleaseCouponRedemptionsTx(ctx, tx, orderID); err != nil {
+			return nil, err
+		}
+		if actor != constant.OrderActorSaga {
+			if err := s.abortSagaTx(ctx, tx, orderID, reason); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.OrderRepo.UpdateOrderStatusTx(ctx, tx, orderID, status); err != nil {
+		return nil, err
+	}
+	err := s.OrderRepo.AppendOrderHistoryTx(ctx, tx, order.OrderDetailID, models.StatusHistory{
+		Status:    status,
+		Timestamp: time.Now().Format(time.RFC3339),
+		Actor:     actor,
+		Reason:    reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statusChangedEvent, err := kafka.NewOrderStatusChangedOutboxEvent(order, order.Status, status, actor, reason)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.OrderRepo.InsertOrderOutboxEventsTx(ctx, tx, []models.OrderOutboxEvent{statusChangedEvent}); err != nil {
+		return nil, err
+	}
+	err = s.recordAuditTx(ctx, tx, models.OrderAuditLog{
+		OrderID:    orderID,
+		Action:     auditAction,
+		FromStatus: statusPtr(order.Status),
+		ToStatus:   statusPtr(status),
+		Reason:     reason,
+	}, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = status
+	return order, nil
 }
 
 func (s *OrderService) GetOrderInfoByOrderID(ctx context.Context, orderID int64) (*models.Order, error) {
@@ -154,6 +374,123 @@ func (s *OrderService) GetOrderDetailByID(ctx context.Context, orderDetailID int
 	return orderDetail, nil
 }
 
+// GetOrderProducts 주문 상품 목록. order_items가 없는 (backfill 전) 주문은 order_details.products JSON을 사용한다.
+func (s *OrderService) GetOrderProducts(ctx context.Context, order *models.Order) ([]models.CheckoutItem, error) {
+	itemsByOrder, err := s.OrderRepo.GetOrderItemsByOrderIDs(ctx, []int64{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	return orderProducts(order, itemsByOrder[order.ID], func() (*models.OrderDetail, error) {
+		return s.OrderRepo.GetOrderDetailByID(ctx, order.OrderDetailID)
+	})
+}
+
+// GetOrderProductsTx 트랜잭션 안에서 잠근 주문의 상품을 같은 트랜잭션으로 읽는다.
+func (s *OrderService) GetOrderProductsTx(ctx context.Context, tx *gorm.DB, order *models.Order) ([]models.CheckoutItem, error) {
+	itemsByOrder, err := s.OrderRepo.GetOrderItemsByOrderIDsTx(ctx, tx, []int64{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	return orderProducts(order, itemsByOrder[order.ID], func() (*models.OrderDetail, error) {
+		return s.OrderRepo.GetOrderDetailByIDTx(ctx, tx, order.OrderDetailID)
+	})
+}
+
+// StockRollbackOutboxEventTx 주문이 잡고 있는 재고 전체를 되돌리는 stock.rollback 이벤트. 취소/만료/saga 보상/관리자 취소가 같이 쓴다.
+func (s *OrderService) StockRollbackOutboxEventTx(ctx context.Context, tx *gorm.DB, order *models.Order) (models.OrderOutboxEvent, error) {
+	products, err := s.GetOrderProductsTx(ctx, tx, order)
+	if err != nil {
+		return models.OrderOutboxEvent{}, err
+	}
+	productItems := make([]models.ProductItem, 0, len(products))
+	for _, product := range products {
+		productItems = append(productItems, models.ProductItem{ProductID: product.ProductID, Quantity: product.Quantity})
+	}
+	return kafka.NewStockRollbackOutboxEvent(order.ID, order.UserID, productItems)
+}
+
+func orderProducts(order *models.Order, items []models.OrderItem, loadDetail func() (*models.OrderDetail, error)) ([]models.CheckoutItem, error) {
+	if len(items) > 0 {
+		return repository.OrderItemsToCheckoutItems(items, "", order.Currency)
+	}
+	orderDetail, err := loadDetail()
+	if err != nil {
+		return nil, err
+	}
+	return repository.OrderItemsToCheckoutItems(nil, orderDetail.Products, order.Currency)
+}
+
+// BackfillOrderItems order_details.products JSON을 order_items로 옮긴다. 주문 단위 트랜잭션이라 중단 후 재실행해도 안전하다.
+// legacy JSON에는 상품명/SKU가 없어 productfc의 현재 값으로 스냅샷을 채우고 검색 문서를 다시 만든다.
+// 조회되지 않는 상품(삭제 등)은 스냅샷이 비어 상품명으로 검색되지 않는다.
+func (s *OrderService) BackfillOrderItems(ctx context.Context, batchSize int) (int, error) {
+	var migrated int
+	var lastID int64
+	for {
+		orders, err := s.OrderRepo.GetOrdersWithoutItems(ctx, lastID, batchSize)
+		if err != nil {
+			return migrated, err
+		}
+		if len(orders) == 0 {
+			return migrated, nil
+		}
+
+		productsByOrder := make(map[int64][]models.CheckoutItem, len(orders))
+		var productIDs []int64
+		for _, order := range orders {
+			products, err := repository.OrderItemsToCheckoutItems(nil, order.OrderDetail.Products, order.Currency)
+			if err != nil {
+				log.Logger.Warn().Err(err).Int64("order_id", order.ID).Msg("Skipping order with malformed products JSON")
+				continue
+			}
+			productsByOrder[order.ID] = products
+			for _, product := range products {
+				productIDs = append(productIDs, product.ProductID)
+			}
+		}
+		productInfos, itemErrs := s.OrderRepo.GetProductInfos(ctx, productIDs)
+		if len(itemErrs) > 0 {
+			log.Logger.Warn().Int("products", len(itemErrs)).Msg("Backfilling order items without product name snapshot")
+		}
+
+		for _, order := range orders {
+			lastID = order.ID
+			products, ok := productsByOrder[order.ID]
+			if !ok {
+				continue
+			}
+			items := make([]models.OrderItem, 0, len(products))
+			for _, product := range products {
+				productInfo := productInfos[product.ProductID]
+				items = append(items, models.OrderItem{
+					OrderID:     order.ID,
+					ProductID:   product.ProductID,
+					Quantity:    product.Quantity,
+					Currency:    order.Currency,
+					UnitPrice:   product.Price,
+					LineTotal:   product.Price.Mul(product.Quantity),
+					ProductName: productInfo.Name,
+					ProductSKU:  productInfo.SKU,
+				})
+			}
+			err = s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+				if err := s.OrderRepo.InsertOrderItemsTx(ctx, tx, items); err != nil {
+					return err
+				}
+				return s.OrderRepo.RefreshOrderSearchTx(ctx, tx, order.ID)
+			})
+			if err != nil {
+				return migrated, err
+			}
+			migrated++
+		}
+	}
+}
+
 func (s *OrderService) GetDailySalesReport(ctx context.Context, days int) ([]models.DailySalesReport, error) {
 	return s.OrderRepo.GetDailySalesReport(ctx, days)
 }
+
+func (s *OrderService) MigrateLegacyOrderCurrency(ctx context.Context, baseCurrency string) error {
+	return s.OrderRepo.MigrateLegacyOrderCurrency(ctx, baseCurrency)
+}
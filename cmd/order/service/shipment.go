@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShipOrder completed -> shipped 전이와 배송 레코드 저장을 하나의 트랜잭션으로 처리한다.
+func (s *OrderService) ShipOrder(ctx context.Context, shipment *models.Shipment, actor string) error {
+	return s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if _, err := s.TransitionOrderStatusTx(ctx, tx, shipment.OrderID, constant.OrderStatusShipped, actor, "shipped"); err != nil {
+			return err
+		}
+		return s.OrderRepo.UpsertShipmentTx(ctx, tx, shipment)
+	})
+}
+
+// MarkOrderDelivered shipped -> delivered 전이와 배송 완료 시각 기록.
+func (s *OrderService) MarkOrderDelivered(ctx context.Context, orderID int64, deliveredAt time.Time, actor string) error {
+	return s.OrderRepo.WithTransaction(ctx, func(tx *gorm.DB) error {
+		if _, err := s.TransitionOrderStatusTx(ctx, tx, orderID, constant.OrderStatusDelivered, actor, "delivered"); err != nil {
+			return err
+		}
+		return s.OrderRepo.MarkShipmentDeliveredTx(ctx, tx, orderID, deliveredAt)
+	})
+}
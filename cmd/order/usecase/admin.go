@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidStatusOverride = errors.New("status must be a known order status and reason is required")
+
+// OverrideOrderStatus 관리자(지원팀) 상태 변경. 변경 후 주문 상세를 반환한다.
+func (u *OrderUsecase) OverrideOrderStatus(ctx context.Context, adminID, orderID int64, req models.AdminOrderStatusRequest) (*models.OrderHistoryResponse, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if req.Status == nil || reason == "" {
+		return nil, ErrInvalidStatusOverride
+	}
+	if _, ok := constant.OrderStatusMap[*req.Status]; !ok {
+		return nil, ErrInvalidStatusOverride
+	}
+
+	order, err := u.OrderService.OverrideOrderStatus(ctx, orderID, *req.Status, constant.OrderActorAdmin, reason, req.Force)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	log.Logger.Info().
+		Int64("admin_id", adminID).
+		Int64("order_id", orderID).
+		Str("status", constant.OrderStatusMap[order.Status]).
+		Bool("force", req.Force).
+		Str("reason", reason).
+		Msg("Order status overridden by admin")
+
+	return u.GetOrderByID(ctx, adminID, orderID, true)
+}
+
+// GetOrderAuditLog 주문의 감사 로그 전체를 기록 순서대로 반환한다.
+func (u *OrderUsecase) GetOrderAuditLog(ctx context.Context, orderID int64) (*models.OrderAuditLogResponse, error) {
+	if _, err := u.OrderService.GetOrderInfoByOrderID(ctx, orderID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	entries, err := u.OrderService.GetOrderAuditLogs(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []models.OrderAuditLog{}
+	}
+	return &models.OrderAuditLogResponse{OrderID: orderID, Entries: entries}, nil
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"orderfc/productclient"
+	"testing"
+)
+
+func statusOverride(status int, force bool) models.AdminOrderStatusRequest {
+	return models.AdminOrderStatusRequest{Status: &status, Reason: "support ticket", Force: force}
+}
+
+// 관리자 취소도 고객 취소처럼 예약된 재고를 되돌리고, 취소된 주문은 force로도 되살릴 수 없다.
+func TestOverrideOrderStatusCancelReleasesStock(t *testing.T) {
+	ctx := context.Background()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+	orderID, err := u.CheckOutOrder(ctx, checkoutRequest("override-1", models.CheckoutItem{ProductID: 1, Quantity: 2, Price: 10000}))
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+	if err := u.OrderService.HandleStockReserved(ctx, stockReserved(orderID, "23000")); err != nil {
+		t.Fatalf("HandleStockReserved: %v", err)
+	}
+
+	if _, err := u.OverrideOrderStatus(ctx, 99, orderID, statusOverride(constant.OrderStatusCancelled, false)); err != nil {
+		t.Fatalf("OverrideOrderStatus: %v", err)
+	}
+	if status := store.orders[orderID].Status; status != constant.OrderStatusCancelled {
+		t.Fatalf("order status = %s, want cancelled", constant.OrderStatusMap[status])
+	}
+	if sg := store.sagas[orderID]; sg.Status != models.SagaStatusCompensated {
+		t.Errorf("saga status = %s, want compensated", sg.Status)
+	}
+	topics := store.topics()
+	if topics[len(topics)-1] != "stock.rollback" {
+		t.Fatalf("outbox topics = %v, want stock.rollback last", topics)
+	}
+
+	_, err = u.OverrideOrderStatus(ctx, 99, orderID, statusOverride(constant.OrderStatusProcessing, true))
+	if !errors.Is(err, service.ErrInvalidStatusTransition) {
+		t.Fatalf("forced resurrection err = %v, want ErrInvalidStatusTransition", err)
+	}
+	if status := store.orders[orderID].Status; status != constant.OrderStatusCancelled {
+		t.Errorf("order status = %s after rejected override", constant.OrderStatusMap[status])
+	}
+	if len(store.topics()) != len(topics) {
+		t.Errorf("rejected override wrote outbox events: %v", store.topics()[len(topics):])
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"strings"
+)
+
+var ErrNothingToAmend = errors.New("nothing to amend")
+
+// AmendOrder 결제 전 주문의 상품/수량, 결제 수단, 배송지를 변경한다. 체크아웃과 같은 검증/가격 계산을 다시 거치며,
+// 환율은 체크아웃 시점 스냅샷을 그대로 쓰고 이미 적용된 쿠폰은 다시 평가한다.
+func (u *OrderUsecase) AmendOrder(ctx context.Context, userID, orderID int64, req models.AmendOrderRequest) (*models.OrderHistoryResponse, error) {
+	var err error
+	for attempt := 0; attempt < maxPromotionRepricing; attempt++ {
+		err = u.amendOrder(ctx, userID, orderID, req)
+		if !errors.Is(err, service.ErrAutoPromotionExhausted) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return u.GetOrderByID(ctx, userID, orderID, false)
+}
+
+// amendOrder 주문을 다시 읽어 가격을 계산하고 변경을 저장한다.
+func (u *OrderUsecase) amendOrder(ctx context.Context, userID, orderID int64, req models.AmendOrderRequest) error {
+	order, err := u.getOwnedOrder(ctx, userID, orderID)
+	if err != nil {
+		return err
+	}
+	if order.Status != constant.OrderStatusCreated {
+		return fmt.Errorf("%w: order is %s", service.ErrOrderNotAmendable, constant.OrderStatusMap[order.Status])
+	}
+
+	currentItems, err := u.OrderService.GetOrderProducts(ctx, order)
+	if err != nil {
+		return err
+	}
+	held := make(map[int64]models.CheckoutItem, len(currentItems))
+	for _, item := range currentItems {
+		heldItem := held[item.ProductID]
+		heldItem.ProductID = item.ProductID
+		heldItem.Quantity += item.Quantity
+		heldItem.Price = item.Price
+		held[item.ProductID] = heldItem
+	}
+
+	checkoutRequest := &models.CheckoutRequest{
+		UserID:          userID,
+		Items:           currentItems,
+		PaymentMethod:   order.PaymentMethod,
+		ShippingAddress: orderShippingAddress(order),
+		Currency:        order.Currency,
+	}
+	var changes []string
+	if req.Items != nil {
+		checkoutRequest.Items = req.Items
+		changes = append(changes, "items")
+	}
+	if req.PaymentMethod != "" && req.PaymentMethod != order.PaymentMethod {
+		checkoutRequest.PaymentMethod = req.PaymentMethod
+		changes = append(changes, "payment_method")
+	}
+	if req.ShippingAddress != nil {
+		checkoutRequest.ShippingAddress = *req.ShippingAddress
+		changes = append(changes, "shipping_address")
+	}
+	if len(changes) == 0 {
+		return ErrNothingToAmend
+	}
+
+	checkoutRequest.CouponCodes, err = u.explicitCouponCodes(ctx, orderID)
+	if err != nil {
+		return err
+	}
+
+	checkoutCurrency := checkoutCurrency{Currency: order.Currency, BaseCurrency: order.BaseCurrency, Rate: order.ExchangeRate}
+	pricing, err := u.priceCheckout(ctx, checkoutRequest, checkoutCurrency, pricingOptions{Held: held, ExcludeOrderID: orderID})
+	if err != nil {
+		return err
+	}
+
+	products, err := json.Marshal(checkoutRequest.Items)
+	if err != nil {
+		return err
+	}
+	order.PaymentMethod = checkoutRequest.PaymentMethod
+	order.ShippingAddress = checkoutRequest.ShippingAddress.Format()
+	order.Shipping = checkoutRequest.ShippingAddress
+	pricing.apply(order)
+
+	err = u.OrderService.AmendOrder(ctx, service.OrderAmendment{
+		Order:    order,
+		Products: string(products),
+		Components: service.OrderComponents{
+			Items:     buildOrderItems(checkoutRequest.Items, pricing.ProductInfos, order.Currency),
+			Discounts: pricing.Discounts,
+			TaxLines:  pricing.TaxLines,
+		},
+		Reason: "amended: " + strings.Join(changes, ", "),
+	})
+	if err != nil {
+		return err
+	}
+	// 재고 증감 이벤트가 나갔으므로 관련 상품 캐시를 비운다.
+	u.OrderService.InvalidateProductCache(ctx, amendedProductIDs(currentItems, checkoutRequest.Items)...)
+	return nil
+}
+
+// explicitCouponCodes 주문에 적용된 할인 중 고객이 직접 입력한 쿠폰 코드. 자동 적용 프로모션은 재평가 시 다시 붙는다.
+func (u *OrderUsecase) explicitCouponCodes(ctx context.Context, orderID int64) ([]string, error) {
+	discounts, err := u.OrderService.GetOrderDiscounts(ctx, orderID)
+	if err != nil || len(discounts) == 0 {
+		return nil, err
+	}
+	codes := make([]string, 0, len(discounts))
+	for _, discount := range discounts {
+		codes = append(codes, discount.Code)
+	}
+	promotions, err := u.OrderService.GetPromotionsByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	var explicit []string
+	for _, p := range promotions {
+		if !p.AutoApply {
+			explicit = append(explicit, p.Code)
+		}
+	}
+	return explicit, nil
+}
+
+func amendedProductIDs(before, after []models.CheckoutItem) []int64 {
+	seen := make(map[int64]bool, len(before)+len(after))
+	ids := make([]int64, 0, len(before)+len(after))
+	for _, item := range append(append([]models.CheckoutItem{}, before...), after...) {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			ids = append(ids, item.ProductID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"orderfc/productclient"
+	"testing"
+)
+
+// checkoutAndAmend 1개로 체크아웃(13000)한 뒤 stock.reserved 전에 2개로 변경(23000)한다.
+func checkoutAndAmend(t *testing.T) (*OrderUsecase, *memoryStore, int64) {
+	t.Helper()
+	ctx := context.Background()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+
+	orderID, err := u.CheckOutOrder(ctx, checkoutRequest("amend-1", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000}))
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+	amended, err := u.AmendOrder(ctx, 7, orderID, models.AmendOrderRequest{
+		Items: []models.CheckoutItem{{ProductID: 1, Quantity: 2, Price: 10000}},
+	})
+	if err != nil {
+		t.Fatalf("AmendOrder: %v", err)
+	}
+	if amended.TotalAmount != 23000 {
+		t.Fatalf("amended total = %d, want 23000", amended.TotalAmount)
+	}
+	if order := store.orders[orderID]; order.ReservationAmount != 13000 {
+		t.Fatalf("reservation amount = %d, want the order.created total 13000", order.ReservationAmount)
+	}
+	return u, store, orderID
+}
+
+func stockReserved(orderID int64, total string) models.StockReservationEvent {
+	return models.StockReservationEvent{
+		SchemaVersion: models.StockReservationMinorUnitVersion,
+		OrderID:       orderID,
+		UserID:        7,
+		TotalAmount:   json.Number(total),
+		Products:      []models.ProductItem{{ProductID: 1, Quantity: 1}},
+	}
+}
+
+// productfc의 stock.reserved는 order.created 금액을 싣고 오므로 변경된 주문도 결제 단계로 진행해야 한다.
+func TestAmendedOrderAdvancesOnOriginalReservation(t *testing.T) {
+	u, store, orderID := checkoutAndAmend(t)
+
+	if err := u.OrderService.HandleStockReserved(context.Background(), stockReserved(orderID, "13000")); err != nil {
+		t.Fatalf("HandleStockReserved: %v", err)
+	}
+
+	if status := store.orders[orderID].Status; status != constant.OrderStatusProcessing {
+		t.Fatalf("order status = %s, want processing", constant.OrderStatusMap[status])
+	}
+	sg := store.sagas[orderID]
+	if sg.Status != models.SagaStatusRunning || sg.CurrentStep != models.SagaStepRequestPayment {
+		t.Errorf("saga = %s/%s, want running/request_payment (last error %q)", sg.Status, sg.CurrentStep, sg.LastError)
+	}
+	topics := store.topics()
+	want := []string{"order.created", "stock.updated", "order.status_changed", "payment.requested"}
+	if len(topics) != len(want) || topics[1] != want[1] || topics[3] != want[3] {
+		t.Fatalf("outbox topics = %v, want %v", topics, want)
+	}
+	var requested models.PaymentRequestedEvent
+	if err := json.Unmarshal([]byte(store.outbox[3].Payload), &requested); err != nil {
+		t.Fatal(err)
+	}
+	if requested.Amount != 23000 {
+		t.Errorf("payment.requested amount = %d, want amended total 23000", requested.Amount)
+	}
+}
+
+func TestAmendedOrderCancelsOnReservationMismatch(t *testing.T) {
+	u, store, orderID := checkoutAndAmend(t)
+
+	if err := u.OrderService.HandleStockReserved(context.Background(), stockReserved(orderID, "12000")); err != nil {
+		t.Fatalf("HandleStockReserved: %v", err)
+	}
+
+	if status := store.orders[orderID].Status; status != constant.OrderStatusCancelled {
+		t.Fatalf("order status = %s, want cancelled", constant.OrderStatusMap[status])
+	}
+	if sg := store.sagas[orderID]; sg.Status != models.SagaStatusCompensated {
+		t.Errorf("saga status = %s, want compensated", sg.Status)
+	}
+	if topics := store.topics(); topics[len(topics)-1] != "stock.rollback" {
+		t.Errorf("outbox topics = %v, want stock.rollback last", topics)
+	}
+}
+
+// 구조화 이전 주문(한 줄 주소만 있음)도 배송지를 바꾸지 않는 변경은 기존 주소 그대로 통과한다.
+func TestAmendLegacyAddressOrderKeepsAddress(t *testing.T) {
+	ctx := context.Background()
+	u, store, orderID := checkoutOne(t, "amend-legacy")
+	legacy := store.orders[orderID]
+	legacy.Shipping = models.ShippingAddress{}
+	legacy.ShippingAddress = "서울시 중구 세종대로 110"
+
+	amended, err := u.AmendOrder(ctx, 7, orderID, models.AmendOrderRequest{PaymentMethod: "bank_transfer"})
+	if err != nil {
+		t.Fatalf("AmendOrder: %v", err)
+	}
+	if amended.TotalAmount != 13000 {
+		t.Errorf("amended total = %d, want 13000", amended.TotalAmount)
+	}
+	if order := store.orders[orderID]; order.ShippingAddress != "서울시 중구 세종대로 110" || order.PaymentMethod != "bank_transfer" {
+		t.Errorf("address/payment = %q/%q", order.ShippingAddress, order.PaymentMethod)
+	}
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"orderfc/cmd/order/repository"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCartEmpty       = errors.New("cart is empty")
+	ErrInvalidCartItem = errors.New("invalid cart item")
+	ErrCartItemMissing = errors.New("product is not in cart")
+)
+
+const (
+	defaultCartTTL  = 7 * 24 * time.Hour
+	maxCartItems    = 100
+	maxCartItemQty  = 1000
+	cartTokenPrefix = "cart-"
+)
+
+func (u *OrderUsecase) cartTTL() time.Duration {
+	if u.CartConfig.TTL > 0 {
+		return u.CartConfig.TTL
+	}
+	return defaultCartTTL
+}
+
+func (u *OrderUsecase) GetCart(ctx context.Context, userID int64) (*models.Cart, error) {
+	return u.OrderService.GetCart(ctx, userID)
+}
+
+// ReplaceCart 장바구니 전체를 요청 내용으로 교체한다. 같은 상품은 수량을 합친다.
+func (u *OrderUsecase) ReplaceCart(ctx context.Context, userID int64, req models.UpdateCartRequest) (*models.Cart, error) {
+	if req.Currency != "" {
+		checkoutCurrency, err := u.resolveCheckoutCurrency(ctx, req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		req.Currency = checkoutCurrency.Currency
+	}
+	if req.ShippingAddress != nil {
+		if err := normalizeShippingAddress(req.ShippingAddress); err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	items := make([]models.CartItem, 0, len(req.Items))
+	for _, requested := range req.Items {
+		if err := u.validateCartItem(ctx, requested.ProductID, requested.Quantity); err != nil {
+			return nil, err
+		}
+		items = mergeCartItem(items, models.CartItem{ProductID: requested.ProductID, Quantity: requested.Quantity, AddedAt: now})
+	}
+	if err := checkCartSize(items); err != nil {
+		return nil, err
+	}
+
+	return u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+		for i, item := range items {
+			if existing := findCartItem(cart.Items, item.ProductID); existing != nil {
+				items[i].AddedAt = existing.AddedAt
+			}
+		}
+		cart.Items = items
+		cart.Currency = req.Currency
+		cart.PaymentMethod = req.PaymentMethod
+		cart.ShippingAddress = req.ShippingAddress
+		cart.CouponCodes = req.CouponCodes
+		cart.CheckoutToken = ""
+		return nil
+	})
+}
+
+func (u *OrderUsecase) ClearCart(ctx context.Context, userID int64) error {
+	return u.OrderService.DeleteCart(ctx, userID)
+}
+
+// AddCartItem 이미 담긴 상품이면 수량을 더한다.
+func (u *OrderUsecase) AddCartItem(ctx context.Context, userID int64, req models.CartItemRequest) (*models.Cart, error) {
+	if err := u.validateCartItem(ctx, req.ProductID, req.Quantity); err != nil {
+		return nil, err
+	}
+	return u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+		cart.Items = mergeCartItem(cart.Items, models.CartItem{ProductID: req.ProductID, Quantity: req.Quantity, AddedAt: time.Now()})
+		cart.CheckoutToken = ""
+		return checkCartSize(cart.Items)
+	})
+}
+
+func (u *OrderUsecase) UpdateCartItem(ctx context.Context, userID, productID int64, quantity int) (*models.Cart, error) {
+	if quantity <= 0 || quantity > maxCartItemQty {
+		return nil, fmt.Errorf("%w: quantity must be between 1 and %d", ErrInvalidCartItem, maxCartItemQty)
+	}
+	return u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+		item := findCartItem(cart.Items, productID)
+		if item == nil {
+			return ErrCartItemMissing
+		}
+		item.Quantity = quantity
+		cart.CheckoutToken = ""
+		return nil
+	})
+}
+
+func (u *OrderUsecase) RemoveCartItem(ctx context.Context, userID, productID int64) (*models.Cart, error) {
+	return u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+		for i, item := range cart.Items {
+			if item.ProductID == productID {
+				cart.Items = append(cart.Items[:i], cart.Items[i+1:]...)
+				cart.CheckoutToken = ""
+				return nil
+			}
+		}
+		return ErrCartItemMissing
+	})
+}
+
+// CheckOutCart 장바구니를 현재 상품 가격의 CheckoutRequest로 바꿔 주문한다. 멱등성 토큰은 장바구니에 고정되므로
+// 재시도해도 같은 주문이 반환되고, 주문이 생성되면 장바구니를 비운다. 결제 수단/배송지 override가 있으면
+// override별로 다른 키를 써서 요청 해시가 달라져도 키 재사용 에러가 나지 않는다.
+func (u *OrderUsecase) CheckOutCart(ctx context.Context, userID int64, req models.CartCheckoutRequest) (int64, error) {
+	cart, err := u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+		if len(cart.Items) == 0 {
+			return ErrCartEmpty
+		}
+		if cart.CheckoutToken == "" {
+			cart.CheckoutToken = cartTokenPrefix + uuid.NewString()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	checkoutCurrency, err := u.resolveCheckoutCurrency(ctx, cart.Currency)
+	if err != nil {
+		return 0, err
+	}
+	checkoutRequest := &models.CheckoutRequest{
+		UserID:           userID,
+		Items:            make([]models.CheckoutItem, 0, len(cart.Items)),
+		PaymentMethod:    cart.PaymentMethod,
+		Currency:         checkoutCurrency.Currency,
+		CouponCodes:      cart.CouponCodes,
+		IdempotencyToken: cartCheckoutKey(cart.CheckoutToken, req),
+	}
+	if req.PaymentMethod != "" {
+		checkoutRequest.PaymentMethod = req.PaymentMethod
+	}
+	if req.ShippingAddress != nil {
+		checkoutRequest.ShippingAddress = *req.ShippingAddress
+	} else if cart.ShippingAddress != nil {
+		checkoutRequest.ShippingAddress = *cart.ShippingAddress
+	}
+	productIDs := make([]int64, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	// 사라진 상품은 가격 없이 넘겨 체크아웃 검증이 다른 문제 상품과 함께 not_found로 알리게 한다.
+	products, lookupErrs := u.OrderService.GetProductInfos(ctx, productIDs)
+	for _, item := range cart.Items {
+		if err := lookupErrs[item.ProductID]; err != nil && !errors.Is(err, repository.ErrProductNotFound) {
+			return 0, err
+		}
+		product := products[item.ProductID]
+		checkoutRequest.Items = append(checkoutRequest.Items, models.CheckoutItem{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Price:     checkoutCurrency.unitPrice(product),
+		})
+	}
+
+	orderID, err := u.CheckOutOrder(ctx, checkoutRequest)
+	token := cart.CheckoutToken
+	if err != nil {
+		// 토큰은 주문이 생성되지 않은 것이 확실할 때(저장 트랜잭션 실패)만 푼다. 그 밖의 실패는 예약이 풀리거나
+		// 이전 결과가 재생되므로 같은 토큰으로 재시도해야 중복 주문이 생기지 않는다.
+		if errors.Is(err, ErrIdempotencyPreviousFail) {
+			if _, resetErr := u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+				if cart.CheckoutToken == token {
+					cart.CheckoutToken = ""
+				}
+				return nil
+			}); resetErr != nil {
+				log.Logger.Warn().Err(resetErr).Int64("user_id", userID).Msg("Failed to reset cart checkout token")
+			}
+		}
+		return 0, err
+	}
+
+	// 체크아웃 도중 장바구니가 바뀌었으면(토큰 초기화) 새 내용은 남겨 둔다.
+	if _, err := u.OrderService.UpdateCart(ctx, userID, u.cartTTL(), func(cart *models.Cart) error {
+		if cart.CheckoutToken == token {
+			*cart = models.Cart{UserID: userID}
+		}
+		return nil
+	}); err != nil {
+		log.Logger.Warn().Err(err).Int64("user_id", userID).Int64("order_id", orderID).Msg("Failed to clear cart after checkout")
+	}
+	return orderID, nil
+}
+
+// cartCheckoutKey 장바구니 토큰에 체크아웃 override를 더한 멱등성 키. override가 없으면 장바구니 토큰 그대로다.
+func cartCheckoutKey(token string, req models.CartCheckoutRequest) string {
+	if req.PaymentMethod == "" && req.ShippingAddress == nil {
+		return token
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		return token
+	}
+	sum := sha256.Sum256(b)
+	return token + "-" + hex.EncodeToString(sum[:8])
+}
+
+// validateCartItem 수량 범위와 상품 존재 여부만 확인한다. 재고와 가격은 체크아웃 시점에 검증한다.
+func (u *OrderUsecase) validateCartItem(ctx context.Context, productID int64, quantity int) error {
+	if productID <= 0 {
+		return fmt.Errorf("%w: product_id is required", ErrInvalidCartItem)
+	}
+	if quantity <= 0 || quantity > maxCartItemQty {
+		return fmt.Errorf("%w: quantity must be between 1 and %d", ErrInvalidCartItem, maxCartItemQty)
+	}
+	_, err := u.OrderService.GetProductInfo(ctx, productID)
+	return err
+}
+
+func checkCartSize(items []models.CartItem) error {
+	if len(items) > maxCartItems {
+		return fmt.Errorf("%w: cart can hold at most %d products", ErrInvalidCartItem, maxCartItems)
+	}
+	for _, item := range items {
+		if item.Quantity > maxCartItemQty {
+			return fmt.Errorf("%w: quantity must be between 1 and %d", ErrInvalidCartItem, maxCartItemQty)
+		}
+	}
+	return nil
+}
+
+func mergeCartItem(items []models.CartItem, added models.CartItem) []models.CartItem {
+	if existing := findCartItem(items, added.ProductID); existing != nil {
+		existing.Quantity += added.Quantity
+		return items
+	}
+	return append(items, added)
+}
+
+func findCartItem(items []models.CartItem, productID int64) *models.CartItem {
+	for i := range items {
+		if items[i].ProductID == productID {
+			return &items[i]
+		}
+	}
+	return nil
+}
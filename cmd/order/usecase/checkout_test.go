@@ -0,0 +1,423 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"orderfc/cmd/order/repository"
+	"orderfc/cmd/order/service"
+	"orderfc/config"
+	"orderfc/infrastructure/exchangerate"
+	"orderfc/models"
+	"orderfc/productclient"
+	"orderfc/shipping"
+	"orderfc/tax"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryStore 체크아웃 경로에서 쓰는 저장소 메서드만 메모리로 구현한다. 상품 조회는 FakeCatalog를 쓰는
+// 실제 OrderRepository(DB/Redis 없음)를 그대로 써서 productfc 없이 가격/재고 검증을 거친다.
+type memoryStore struct {
+	*repository.OrderRepository
+
+	nextID      int64
+	orders      map[int64]*models.Order
+	details     map[int64]*models.OrderDetail
+	items       map[int64][]models.OrderItem
+	sagas       map[int64]*models.OrderSaga
+	outbox      []models.OrderOutboxEvent
+	idempotency map[string]*models.OrderRequestLog
+}
+
+func newMemoryStore(catalog productclient.ProductCatalog) *memoryStore {
+	return &memoryStore{
+		OrderRepository: repository.NewOrderRepository(nil, nil, catalog, config.ProductConfig{}),
+		orders:          make(map[int64]*models.Order),
+		details:         make(map[int64]*models.OrderDetail),
+		items:           make(map[int64][]models.OrderItem),
+		sagas:           make(map[int64]*models.OrderSaga),
+		idempotency:     make(map[string]*models.OrderRequestLog),
+	}
+}
+
+func (m *memoryStore) id() int64 {
+	m.nextID++
+	return m.nextID
+}
+
+func (m *memoryStore) WithTransaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return fn(nil)
+}
+
+func (m *memoryStore) InsertOrderDetailTx(ctx context.Context, tx *gorm.DB, orderDetail *models.OrderDetail) error {
+	orderDetail.ID = m.id()
+	stored := *orderDetail
+	m.details[orderDetail.ID] = &stored
+	return nil
+}
+
+func (m *memoryStore) InsertOrderTx(ctx context.Context, tx *gorm.DB, order *models.Order) error {
+	order.ID = m.id()
+	stored := *order
+	m.orders[order.ID] = &stored
+	return nil
+}
+
+func (m *memoryStore) InsertOrderItemsTx(ctx context.Context, tx *gorm.DB, items []models.OrderItem) error {
+	for _, item := range items {
+		m.items[item.OrderID] = append(m.items[item.OrderID], item)
+	}
+	return nil
+}
+
+func (m *memoryStore) InsertOrderTaxLinesTx(ctx context.Context, tx *gorm.DB, taxLines []models.OrderTaxLine) error {
+	return nil
+}
+
+func (m *memoryStore) RefreshOrderSearchTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return nil
+}
+
+func (m *memoryStore) InsertOrderAuditLogTx(ctx context.Context, tx *gorm.DB, entry *models.OrderAuditLog) error {
+	return nil
+}
+
+func (m *memoryStore) InsertOrderOutboxEventsTx(ctx context.Context, tx *gorm.DB, events []models.OrderOutboxEvent) error {
+	m.outbox = append(m.outbox, events...)
+	return nil
+}
+
+func (m *memoryStore) InsertOrderSagaTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga) error {
+	sg.ID = m.id()
+	m.sagas[sg.OrderID] = sg
+	return nil
+}
+
+func (m *memoryStore) GetPromotionsByCodes(ctx context.Context, codes []string) ([]models.Promotion, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) GetAutoApplyPromotions(ctx context.Context, now time.Time) ([]models.Promotion, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) ReserveIdempotencyToken(ctx context.Context, idempotencyToken, requestHash string) (*models.OrderRequestLog, bool, error) {
+	if existing, ok := m.idempotency[idempotencyToken]; ok {
+		return existing, false, nil
+	}
+	log := &models.OrderRequestLog{IdempotencyToken: idempotencyToken, RequestHash: requestHash, Status: models.IdempotencyStatusProcessing}
+	m.idempotency[idempotencyToken] = log
+	return log, true, nil
+}
+
+func (m *memoryStore) MarkIdempotencyTokenSucceededTx(ctx context.Context, tx *gorm.DB, idempotencyToken string, orderID int64) error {
+	m.idempotency[idempotencyToken].Status = models.IdempotencyStatusSucceeded
+	m.idempotency[idempotencyToken].OrderID = orderID
+	return nil
+}
+
+func (m *memoryStore) MarkIdempotencyTokenFailed(ctx context.Context, idempotencyToken string, processErr error) error {
+	m.idempotency[idempotencyToken].Status = models.IdempotencyStatusFailed
+	return nil
+}
+
+func (m *memoryStore) ReleaseIdempotencyToken(ctx context.Context, idempotencyToken string) error {
+	delete(m.idempotency, idempotencyToken)
+	return nil
+}
+
+// 아래는 주문 변경과 saga 진행(stock.reserved 이후) 경로에서 쓰는 메서드.
+
+func (m *memoryStore) order(orderID int64) (*models.Order, error) {
+	stored, ok := m.orders[orderID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	order := *stored
+	return &order, nil
+}
+
+func (m *memoryStore) GetOrderInfoByOrderID(ctx context.Context, orderID int64) (*models.Order, error) {
+	return m.order(orderID)
+}
+
+func (m *memoryStore) GetOrderForUpdateTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.Order, error) {
+	return m.order(orderID)
+}
+
+func (m *memoryStore) GetOrderHistoryByOrderID(ctx context.Context, orderID int64) (*models.OrderHistoryResponse, error) {
+	order, err := m.order(orderID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.OrderHistoryResponse{OrderID: order.ID, UserID: order.UserID, TotalAmount: order.Amount, Currency: order.Currency}, nil
+}
+
+func (m *memoryStore) GetOrderItemsByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderItem, error) {
+	itemsByOrder := make(map[int64][]models.OrderItem, len(orderIDs))
+	for _, orderID := range orderIDs {
+		itemsByOrder[orderID] = append([]models.OrderItem(nil), m.items[orderID]...)
+	}
+	return itemsByOrder, nil
+}
+
+func (m *memoryStore) GetOrderItemsByOrderIDsTx(ctx context.Context, tx *gorm.DB, orderIDs []int64) (map[int64][]models.OrderItem, error) {
+	return m.GetOrderItemsByOrderIDs(ctx, orderIDs)
+}
+
+func (m *memoryStore) GetOrderDiscountsByOrderIDs(ctx context.Context, orderIDs []int64) (map[int64][]models.OrderDiscount, error) {
+	return nil, nil
+}
+
+func (m *memoryStore) ReleaseCouponRedemptionsTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return nil
+}
+
+func (m *memoryStore) InsertOrderDiscountsTx(ctx context.Context, tx *gorm.DB, discounts []models.OrderDiscount) error {
+	return nil
+}
+
+func (m *memoryStore) DeleteOrderDiscountsTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return nil
+}
+
+func (m *memoryStore) DeleteOrderItemsTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	delete(m.items, orderID)
+	return nil
+}
+
+func (m *memoryStore) DeleteOrderTaxLinesTx(ctx context.Context, tx *gorm.DB, orderID int64) error {
+	return nil
+}
+
+// UpdateAmendedOrderTx 운영 구현처럼 상태와 예약 금액은 그대로 두고 나머지 금액/배송 정보를 바꾼다.
+func (m *memoryStore) UpdateAmendedOrderTx(ctx context.Context, tx *gorm.DB, order *models.Order) error {
+	stored := m.orders[order.ID]
+	amended := *order
+	amended.Status = stored.Status
+	amended.ReservationAmount = stored.ReservationAmount
+	amended.UpdateTime = time.Now()
+	m.orders[order.ID] = &amended
+	return nil
+}
+
+func (m *memoryStore) UpdateOrderDetailProductsTx(ctx context.Context, tx *gorm.DB, orderDetailID int64, products string) error {
+	m.details[orderDetailID].Products = products
+	return nil
+}
+
+func (m *memoryStore) UpdateOrderStatusTx(ctx context.Context, tx *gorm.DB, orderID int64, status int) error {
+	m.orders[orderID].Status = status
+	m.orders[orderID].UpdateTime = time.Now()
+	return nil
+}
+
+func (m *memoryStore) AppendOrderHistoryTx(ctx context.Context, tx *gorm.DB, orderDetailID int64, entry models.StatusHistory) error {
+	return nil
+}
+
+func (m *memoryStore) GetOrderSagaForUpdateTx(ctx context.Context, tx *gorm.DB, orderID int64) (*models.OrderSaga, error) {
+	sg, ok := m.sagas[orderID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return sg, nil
+}
+
+func (m *memoryStore) SaveOrderSagaTx(ctx context.Context, tx *gorm.DB, sg *models.OrderSaga) error {
+	m.sagas[sg.OrderID] = sg
+	return nil
+}
+
+func (m *memoryStore) DeferOrderSagaRecovery(ctx context.Context, orderID int64, baseDelay, maxDelay time.Duration) error {
+	return nil
+}
+
+func (m *memoryStore) topics() []string {
+	topics := make([]string, 0, len(m.outbox))
+	for _, event := range m.outbox {
+		topics = append(topics, event.Topic)
+	}
+	return topics
+}
+
+func newCheckoutUsecase(t *testing.T, catalog productclient.ProductCatalog) (*OrderUsecase, *memoryStore) {
+	t.Helper()
+	rates, err := exchangerate.NewStaticProvider("KRW", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taxCalculator, err := tax.NewRuleBasedCalculator(config.TaxConfig{
+		DefaultRegion: "KR",
+		Regions:       map[string]config.TaxRegionConfig{"KR": {RateBps: 1000, Inclusive: true}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	shippingCalculator := shipping.NewRuleBasedCalculator(config.ShippingConfig{
+		Default:   config.ShippingRateConfig{FlatFee: 30000, PerKgFee: 5000},
+		Countries: map[string]config.ShippingRateConfig{"KR": {FlatFee: 3000}},
+	}, "KR")
+	store := newMemoryStore(catalog)
+	u := NewOrderUsecase(*service.NewOrderService(store), nil, rates, taxCalculator, shippingCalculator, config.CartConfig{}, config.QuoteConfig{})
+	return u, store
+}
+
+func checkoutRequest(token string, items ...models.CheckoutItem) *models.CheckoutRequest {
+	return &models.CheckoutRequest{
+		UserID:        7,
+		Items:         items,
+		PaymentMethod: "card",
+		ShippingAddress: models.ShippingAddress{
+			Recipient:  "홍길동",
+			Phone:      "010-1234-5678",
+			Line1:      "세종대로 110",
+			City:       "서울",
+			PostalCode: "04524",
+			Country:    "KR",
+		},
+		IdempotencyToken: token,
+	}
+}
+
+func TestCheckOutOrderPricesWithCatalog(t *testing.T) {
+	ctx := context.Background()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", SKU: "KB-1", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+
+	orderID, err := u.CheckOutOrder(ctx, checkoutRequest("checkout-1", models.CheckoutItem{ProductID: 1, Quantity: 2, Price: 10000}))
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+
+	order := store.orders[orderID]
+	if order == nil {
+		t.Fatalf("order %d was not stored", orderID)
+	}
+	// KR은 세금 포함 가격이라 세금은 20000에서 역산하고 합계에는 배송비만 더한다.
+	if order.Amount != 23000 || order.TaxAmount != 1818 || order.ShippingFee != 3000 {
+		t.Errorf("amount/tax/shipping = %d/%d/%d, want 23000/1818/3000", order.Amount, order.TaxAmount, order.ShippingFee)
+	}
+	if items := store.items[orderID]; len(items) != 1 || items[0].ProductName != "키보드" || items[0].LineTotal != 20000 {
+		t.Errorf("order items = %+v", items)
+	}
+	if sg := store.sagas[orderID]; sg == nil || sg.Status != models.SagaStatusRunning {
+		t.Errorf("saga = %+v, want running saga", sg)
+	}
+	if len(store.outbox) != 1 || store.outbox[0].Topic != "order.created" {
+		t.Fatalf("outbox = %+v, want one order.created", store.outbox)
+	}
+	var created models.OrderCreatedEvent
+	if err := json.Unmarshal([]byte(store.outbox[0].Payload), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.TotalAmount != order.Amount || len(created.Products) != 1 {
+		t.Errorf("order.created = %+v", created)
+	}
+
+	// 같은 멱등 키 재시도는 상품을 다시 조회하지 않고 같은 주문을 돌려준다.
+	calls := catalog.Calls()
+	replayed, err := u.CheckOutOrder(ctx, checkoutRequest("checkout-1", models.CheckoutItem{ProductID: 1, Quantity: 2, Price: 10000}))
+	if err != nil || replayed != orderID {
+		t.Fatalf("replay = %d, %v; want %d", replayed, err, orderID)
+	}
+	if catalog.Calls() != calls || len(store.orders) != 1 {
+		t.Errorf("replay priced again: calls %d -> %d, orders %d", calls, catalog.Calls(), len(store.orders))
+	}
+}
+
+func TestCheckOutOrderRejectsStalePrice(t *testing.T) {
+	ctx := context.Background()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+	catalog.Set(models.Product{ID: 1, Name: "키보드", Price: 12000, Stock: 5})
+
+	_, err := u.CheckOutOrder(ctx, checkoutRequest("checkout-2", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000}))
+	var validationErr *CheckoutValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want CheckoutValidationError", err)
+	}
+	if len(validationErr.Items) != 1 || validationErr.Items[0].Code != models.CheckoutProblemPriceMismatch || validationErr.Items[0].CurrentPrice != 12000 {
+		t.Errorf("problems = %+v", validationErr.Items)
+	}
+	if len(store.orders) != 0 {
+		t.Fatalf("stored %d orders for rejected checkout", len(store.orders))
+	}
+
+	// 저장 전에 거절된 요청은 멱등 키를 풀어 두므로 현재 가격으로 같은 키를 다시 쓸 수 있다.
+	if _, err := u.CheckOutOrder(ctx, checkoutRequest("checkout-2", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 12000})); err != nil {
+		t.Fatalf("retry with current price: %v", err)
+	}
+}
+
+func TestCheckOutOrderCatalogUnavailable(t *testing.T) {
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Price: 10000, Stock: 5})
+	catalog.FailWith(productclient.ErrUnavailable)
+	u, store := newCheckoutUsecase(t, catalog)
+
+	_, err := u.CheckOutOrder(context.Background(), checkoutRequest("", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000}))
+	if !errors.Is(err, productclient.ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+	if len(store.orders) != 0 {
+		t.Errorf("stored %d orders while catalog was unavailable", len(store.orders))
+	}
+}
+
+// 같은 멱등 키 재시도는 견적 토큰이 그사이 만료됐어도 견적을 다시 검증하지 않고 저장된 주문을 돌려준다.
+func TestCheckOutOrderReplaysAfterQuoteExpired(t *testing.T) {
+	ctx := context.Background()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+	u.QuoteConfig = config.QuoteConfig{Secret: "quote-test", TTL: time.Minute}
+	now := time.Now()
+	u.now = func() time.Time { return now }
+
+	quote, err := u.Quote(ctx, checkoutRequest("", models.CheckoutItem{ProductID: 1, Quantity: 1}))
+	if err != nil {
+		t.Fatalf("Quote: %v", err)
+	}
+	req := func() *models.CheckoutRequest {
+		req := checkoutRequest("quoted-1", models.CheckoutItem{ProductID: 1, Quantity: 1})
+		req.QuoteToken = quote.QuoteToken
+		return req
+	}
+	orderID, err := u.CheckOutOrder(ctx, req())
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+
+	now = quote.ExpiresAt
+	fresh := checkoutRequest("quoted-2", models.CheckoutItem{ProductID: 1, Quantity: 1})
+	fresh.QuoteToken = quote.QuoteToken
+	if _, err := u.CheckOutOrder(ctx, fresh); !errors.Is(err, ErrQuoteExpired) {
+		t.Fatalf("new checkout with expired quote: err = %v, want ErrQuoteExpired", err)
+	}
+
+	replayed, err := u.CheckOutOrder(ctx, req())
+	if err != nil || replayed != orderID {
+		t.Fatalf("replay = %d, %v; want %d", replayed, err, orderID)
+	}
+	if len(store.orders) != 1 {
+		t.Errorf("stored %d orders, want 1", len(store.orders))
+	}
+}
+
+// 구조화 이전 문자열 주소는 국가가 없어도 세금과 같은 국내 기준으로 배송비를 계산한다.
+func TestCheckOutOrderLegacyAddressUsesHomeRegion(t *testing.T) {
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5, Weight: 1200})
+	u, store := newCheckoutUsecase(t, catalog)
+	req := checkoutRequest("legacy-address", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000})
+	req.ShippingAddress = models.ShippingAddress{Text: "서울시 중구 세종대로 110"}
+
+	orderID, err := u.CheckOutOrder(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+	if order := store.orders[orderID]; order.ShippingFee != 3000 || order.TaxAmount != 909 || order.Amount != 13000 {
+		t.Errorf("shipping/tax/amount = %d/%d/%d, want 3000/909/13000", order.ShippingFee, order.TaxAmount, order.Amount)
+	}
+}
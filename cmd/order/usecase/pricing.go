@@ -0,0 +1,212 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"orderfc/cmd/order/repository"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"orderfc/tax"
+)
+
+// checkoutPricing 검증과 금액 계산 결과 (주문 통화 minor unit). 체크아웃과 주문 변경이 같은 파이프라인을 쓴다.
+type checkoutPricing struct {
+	Currency       checkoutCurrency
+	ProductInfos   map[int64]models.Product
+	Discounts      []models.OrderDiscount
+	TaxLines       []models.OrderTaxLine
+	TotalQty       int
+	Subtotal       money.Amount
+	DiscountAmount money.Amount
+	TaxAmount      money.Amount
+	ShippingFee    money.Amount
+	TotalAmount    money.Amount
+	BaseAmount     money.Amount
+	BaseTaxAmount  money.Amount
+}
+
+// pricingOptions 주문 변경 시 기존 주문이 이미 점유한 재고/쿠폰 사용을 검증에서 제외하기 위한 값.
+// Quoted는 견적 토큰으로 보장된 상품별 단가로, 현재 가격과 달라도 유효한 가격으로 인정한다.
+type pricingOptions struct {
+	Held           map[int64]models.CheckoutItem
+	ExcludeOrderID int64
+	Quoted         map[int64]money.Amount
+}
+
+// priceCheckout 배송지 검증 -> 상품 검증 -> 프로모션 -> 세금 -> 배송비 순으로 주문 금액을 계산한다.
+// Amount = 상품 합계 - 할인 + 별도 가산 세금 + 배송비. 세금 포함 지역은 가격에 세금이 이미 들어 있다.
+func (u *OrderUsecase) priceCheckout(ctx context.Context, checkoutRequest *models.CheckoutRequest, checkoutCurrency checkoutCurrency, opts pricingOptions) (*checkoutPricing, error) {
+	if err := normalizeShippingAddress(&checkoutRequest.ShippingAddress); err != nil {
+		return nil, err
+	}
+	country := checkoutRequest.ShippingAddress.Country
+
+	productInfos, err := u.validateProducts(ctx, checkoutRequest.Items, checkoutCurrency, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	discounts, err := u.applyPromotions(ctx, checkoutRequest, checkoutCurrency, opts.ExcludeOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &checkoutPricing{Currency: checkoutCurrency, ProductInfos: productInfos, Discounts: discounts}
+	p.TotalQty, p.Subtotal = u.calculateItemSummary(ctx, checkoutRequest.Items)
+	p.DiscountAmount = sumDiscounts(discounts)
+	p.TaxLines, err = u.calculateTax(ctx, checkoutRequest.Items, productInfos, p.DiscountAmount, country)
+	if err != nil {
+		return nil, err
+	}
+	p.TaxAmount = tax.SumTax(p.TaxLines)
+	p.ShippingFee, err = u.calculateShippingFee(ctx, checkoutRequest.Items, productInfos, p.Subtotal-p.DiscountAmount, country, checkoutCurrency)
+	if err != nil {
+		return nil, err
+	}
+	p.TotalAmount = p.Subtotal - p.DiscountAmount + tax.SumExclusiveTax(p.TaxLines) + p.ShippingFee
+
+	toBase := checkoutCurrency.Rate.Inverse()
+	p.BaseAmount = money.Convert(p.TotalAmount, checkoutCurrency.Currency, checkoutCurrency.BaseCurrency, toBase)
+	p.BaseTaxAmount = money.Convert(p.TaxAmount, checkoutCurrency.Currency, checkoutCurrency.BaseCurrency, toBase)
+	return p, nil
+}
+
+// apply 계산된 금액과 환율 스냅샷을 주문에 반영한다.
+func (p *checkoutPricing) apply(order *models.Order) {
+	order.Amount = p.TotalAmount
+	order.Currency = p.Currency.Currency
+	order.BaseCurrency = p.Currency.BaseCurrency
+	order.BaseAmount = p.BaseAmount
+	order.ExchangeRate = p.Currency.Rate
+	order.DiscountAmount = p.DiscountAmount
+	order.TaxAmount = p.TaxAmount
+	order.BaseTaxAmount = p.BaseTaxAmount
+	order.ShippingFee = p.ShippingFee
+	order.TotalQty = p.TotalQty
+}
+
+// CheckoutValidationError 상품 검증에서 발견한 문제를 한 번에 모두 담는다. errors.Is(err, ErrCheckoutValidation)으로 판별한다.
+type CheckoutValidationError struct {
+	Items []models.CheckoutItemProblem
+}
+
+func (e *CheckoutValidationError) Error() string {
+	return fmt.Sprintf("%d checkout item(s) failed validation", len(e.Items))
+}
+
+func (e *CheckoutValidationError) Is(target error) bool {
+	return target == ErrCheckoutValidation
+}
+
+// validateProducts 재고/수량/가격을 검증하고 주문 스냅샷용 상품 정보를 product_id 기준으로 돌려준다.
+// held에 있는 상품은 이미 예약된 수량만큼 재고 검증에서 빼고, 당시 단가도 유효한 가격으로 인정한다. 견적 단가도 마찬가지다.
+// 상품은 한 번에 조회하고, 문제가 있는 상품은 첫 번째에서 멈추지 않고 모두 CheckoutValidationError로 모은다.
+func (u *OrderUsecase) validateProducts(ctx context.Context, items []models.CheckoutItem, checkoutCurrency checkoutCurrency, opts pricingOptions) (map[int64]models.Product, error) {
+	productIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	productInfos, lookupErrs := u.OrderService.GetProductInfos(ctx, productIDs)
+
+	check := func(item models.CheckoutItem, product models.Product) *models.CheckoutItemProblem {
+		heldItem, isHeld := opts.Held[item.ProductID]
+		if product.Stock < item.Quantity-heldItem.Quantity {
+			return &models.CheckoutItemProblem{
+				ProductID: item.ProductID,
+				Code:      models.CheckoutProblemOutOfStock,
+				Message:   fmt.Sprintf("only %d left in stock", product.Stock+heldItem.Quantity),
+			}
+		}
+		currentPrice := checkoutCurrency.unitPrice(product)
+		quotedPrice, isQuoted := opts.Quoted[item.ProductID]
+		if item.Price != currentPrice && !(isHeld && item.Price == heldItem.Price) && !(isQuoted && item.Price == quotedPrice) {
+			return &models.CheckoutItemProblem{
+				ProductID:    item.ProductID,
+				Code:         models.CheckoutProblemPriceMismatch,
+				Message:      fmt.Sprintf("price changed from %d to %d", item.Price, currentPrice),
+				CurrentPrice: currentPrice,
+			}
+		}
+		return nil
+	}
+
+	// 캐시된 상품 정보로 거절하기 전에 productfc에서 다시 확인한다. 재고가 충분해 보여도 최종 차감은
+	// saga의 재고 예약 단계에서 productfc가 판단하므로 캐시 때문에 초과 판매되지는 않는다.
+	var stale []int64
+	for _, item := range items {
+		if product, ok := productInfos[item.ProductID]; ok && check(item, product) != nil {
+			stale = append(stale, item.ProductID)
+		}
+	}
+	if len(stale) > 0 {
+		refreshed, refreshErrs := u.OrderService.RefreshProductInfos(ctx, stale)
+		for productID, product := range refreshed {
+			productInfos[productID] = product
+		}
+		for productID, err := range refreshErrs {
+			delete(productInfos, productID)
+			lookupErrs[productID] = err
+		}
+	}
+
+	var problems []models.CheckoutItemProblem
+	for _, item := range items {
+		if item.Quantity <= 0 || item.Quantity > 1000 {
+			problems = append(problems, models.CheckoutItemProblem{
+				ProductID: item.ProductID,
+				Code:      models.CheckoutProblemInvalidQuantity,
+				Message:   "quantity must be between 1 and 1000",
+			})
+			continue
+		}
+		if err, ok := lookupErrs[item.ProductID]; ok {
+			if !errors.Is(err, repository.ErrProductNotFound) {
+				return nil, err
+			}
+			problems = append(problems, models.CheckoutItemProblem{
+				ProductID: item.ProductID,
+				Code:      models.CheckoutProblemNotFound,
+				Message:   "product not found",
+			})
+			continue
+		}
+		if problem := check(item, productInfos[item.ProductID]); problem != nil {
+			problems = append(problems, *problem)
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &CheckoutValidationError{Items: problems}
+	}
+	return productInfos, nil
+}
+
+// calculateItemSummary 정수 minor unit 합산이라 반올림이 없고, order.created로 전달되는 금액과 항상 일치한다.
+func (u *OrderUsecase) calculateItemSummary(ctx context.Context, items []models.CheckoutItem) (int, money.Amount) {
+	var totalQty int
+	var totalAmount money.Amount
+	for _, item := range items {
+		totalAmount += item.Price.Mul(item.Quantity)
+		totalQty += item.Quantity
+	}
+	return totalQty, totalAmount
+}
+
+// calculateTax 주문 할인을 라인 금액 비율로 배분한 뒤 라인별 과세 금액으로 세금을 계산한다.
+func (u *OrderUsecase) calculateTax(ctx context.Context, items []models.CheckoutItem, productInfos map[int64]models.Product, discountAmount money.Amount, region string) ([]models.OrderTaxLine, error) {
+	lineTotals := make([]money.Amount, 0, len(items))
+	for _, item := range items {
+		lineTotals = append(lineTotals, item.Price.Mul(item.Quantity))
+	}
+	allocated := money.Allocate(discountAmount, lineTotals)
+
+	lines := make([]tax.Line, 0, len(items))
+	for i, item := range items {
+		lines = append(lines, tax.Line{
+			ProductID:     item.ProductID,
+			CategoryID:    productInfos[item.ProductID].CategoryID,
+			TaxableAmount: lineTotals[i] - allocated[i],
+		})
+	}
+	return u.TaxCalculator.Calculate(ctx, tax.Input{Region: region, Lines: lines})
+}
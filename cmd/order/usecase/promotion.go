@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"orderfc/promotion"
+	"strings"
+	"time"
+)
+
+// maxPromotionRepricing 저장 시점에 자동 적용 프로모션이 소진되었을 때 다시 가격을 계산하는 최대 횟수.
+// 재평가는 소진된 프로모션을 건너뛰므로 보통 한 번이면 충분하다.
+const maxPromotionRepricing = 3
+
+// applyPromotions 입력한 쿠폰 코드와 자동 적용 프로모션을 평가해 주문 통화 기준 할인 목록을 만든다.
+// 사용 한도는 여기서 한 번 확인하고, 주문 저장 트랜잭션에서 행 잠금 후 다시 확인한다.
+// excludeOrderID는 주문 변경 시 해당 주문 자신의 사용 기록을 사용 횟수에서 제외한다.
+func (u *OrderUsecase) applyPromotions(ctx context.Context, checkoutRequest *models.CheckoutRequest, checkoutCurrency checkoutCurrency, excludeOrderID int64) ([]models.OrderDiscount, error) {
+	codes := normalizeCouponCodes(checkoutRequest.CouponCodes)
+	checkoutRequest.CouponCodes = codes
+
+	explicit, err := u.OrderService.GetPromotionsByCodes(ctx, codes)
+	if err != nil {
+		return nil, err
+	}
+	if len(explicit) != len(codes) {
+		found := make(map[string]bool, len(explicit))
+		for _, p := range explicit {
+			found[p.Code] = true
+		}
+		for _, code := range codes {
+			if !found[code] {
+				return nil, fmt.Errorf("%w: %s", promotion.ErrCouponNotFound, code)
+			}
+		}
+	}
+
+	now := time.Now()
+	auto, err := u.OrderService.GetAutoApplyPromotions(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]promotion.Candidate, 0, len(explicit)+len(auto))
+	seen := make(map[int64]bool, len(explicit)+len(auto))
+	for _, p := range explicit {
+		seen[p.ID] = true
+		candidates = append(candidates, promotion.Candidate{Promotion: p, Explicit: true})
+	}
+	for _, p := range auto {
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			candidates = append(candidates, promotion.Candidate{Promotion: p})
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	promotionIDs := make([]int64, 0, len(candidates))
+	for _, candidate := range candidates {
+		promotionIDs = append(promotionIDs, candidate.Promotion.ID)
+	}
+	usage, err := u.OrderService.CountUserRedemptions(ctx, checkoutRequest.UserID, promotionIDs, excludeOrderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		candidates[i].UserRedemptions = usage[candidates[i].Promotion.ID]
+	}
+
+	lines := make([]promotion.Line, 0, len(checkoutRequest.Items))
+	for _, item := range checkoutRequest.Items {
+		lines = append(lines, promotion.Line{ProductID: item.ProductID, Quantity: item.Quantity, UnitPrice: item.Price})
+	}
+	return promotion.Evaluate(promotion.Cart{
+		Lines: lines,
+		Now:   now,
+		ToOrderCurrency: func(amount money.Amount) money.Amount {
+			return money.Convert(amount, checkoutCurrency.BaseCurrency, checkoutCurrency.Currency, checkoutCurrency.Rate)
+		},
+	}, candidates)
+}
+
+func normalizeCouponCodes(codes []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		normalized = append(normalized, code)
+	}
+	return normalized
+}
+
+func sumDiscounts(discounts []models.OrderDiscount) money.Amount {
+	var total money.Amount
+	for _, discount := range discounts {
+		total += discount.Amount
+	}
+	return total
+}
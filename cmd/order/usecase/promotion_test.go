@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/cmd/order/service"
+	"orderfc/models"
+	"orderfc/productclient"
+	"orderfc/promotion"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// racingPromotionStore 프로모션 하나를 두고, 행 잠금 시점에 다른 주문이 마지막 사용분을 먼저 가져간 상황을 흉내 낸다.
+type racingPromotionStore struct {
+	*memoryStore
+
+	promotion   models.Promotion
+	raced       bool
+	redemptions int
+}
+
+func (s *racingPromotionStore) GetPromotionsByCodes(ctx context.Context, codes []string) ([]models.Promotion, error) {
+	for _, code := range codes {
+		if code == s.promotion.Code {
+			return []models.Promotion{s.promotion}, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *racingPromotionStore) GetAutoApplyPromotions(ctx context.Context, now time.Time) ([]models.Promotion, error) {
+	if !s.promotion.AutoApply {
+		return nil, nil
+	}
+	return []models.Promotion{s.promotion}, nil
+}
+
+func (s *racingPromotionStore) CountUserRedemptions(ctx context.Context, userID int64, promotionIDs []int64, excludeOrderID int64) (map[int64]int, error) {
+	return nil, nil
+}
+
+func (s *racingPromotionStore) GetPromotionForUpdateTx(ctx context.Context, tx *gorm.DB, promotionID int64) (*models.Promotion, error) {
+	if !s.raced {
+		s.raced = true
+		s.promotion.RedeemedCount++
+	}
+	p := s.promotion
+	return &p, nil
+}
+
+func (s *racingPromotionStore) InsertCouponRedemptionTx(ctx context.Context, tx *gorm.DB, redemption *models.CouponRedemption) error {
+	s.redemptions++
+	return nil
+}
+
+func newRacingPromotionUsecase(t *testing.T, p models.Promotion) (*OrderUsecase, *racingPromotionStore) {
+	t.Helper()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+	racing := &racingPromotionStore{memoryStore: store, promotion: p}
+	u.OrderService = *service.NewOrderService(racing)
+	return u, racing
+}
+
+func lastRedemptionPromotion(code string, autoApply bool) models.Promotion {
+	now := time.Now()
+	return models.Promotion{
+		ID:             1,
+		Code:           code,
+		Type:           models.PromotionTypeFixed,
+		AutoApply:      autoApply,
+		AmountOff:      1000,
+		MaxRedemptions: 1,
+		StartTime:      now.Add(-time.Hour),
+		ExpireTime:     now.Add(time.Hour),
+		Active:         true,
+	}
+}
+
+// 자동 적용 프로모션이 저장 직전에 소진되면 할인 없이 다시 계산해 주문은 성공한다.
+func TestCheckOutOrderDropsExhaustedAutoPromotion(t *testing.T) {
+	u, store := newRacingPromotionUsecase(t, lastRedemptionPromotion("AUTO1000", true))
+
+	orderID, err := u.CheckOutOrder(context.Background(), checkoutRequest("auto-promo-1", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000}))
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+	order := store.orders[orderID]
+	if order.DiscountAmount != 0 || order.Amount != 13000 {
+		t.Errorf("discount/amount = %d/%d, want 0/13000", order.DiscountAmount, order.Amount)
+	}
+	if store.redemptions != 0 {
+		t.Errorf("redemptions = %d, want none for the exhausted promotion", store.redemptions)
+	}
+	if log := store.idempotency["auto-promo-1"]; log == nil || log.Status != models.IdempotencyStatusSucceeded || log.OrderID != orderID {
+		t.Errorf("idempotency = %+v, want succeeded for order %d", log, orderID)
+	}
+}
+
+// 직접 입력한 쿠폰은 다시 계산하지 않고 사용 한도 에러로 실패한다.
+func TestCheckOutOrderFailsExhaustedExplicitCoupon(t *testing.T) {
+	u, store := newRacingPromotionUsecase(t, lastRedemptionPromotion("WELCOME", false))
+
+	req := checkoutRequest("", models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000})
+	req.CouponCodes = []string{"welcome"}
+	_, err := u.CheckOutOrder(context.Background(), req)
+	if !errors.Is(err, promotion.ErrUsageLimitReached) {
+		t.Fatalf("err = %v, want ErrUsageLimitReached", err)
+	}
+	if store.redemptions != 0 {
+		t.Errorf("redemptions = %d, want none", store.redemptions)
+	}
+}
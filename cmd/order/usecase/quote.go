@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"strings"
+	"time"
+)
+
+const (
+	defaultQuoteTTL = 10 * time.Minute
+	quoteVersion    = 1
+)
+
+// quoteClaims 견적 토큰 페이로드. 단가와 환율 스냅샷을 담아 체크아웃 시 그대로 다시 쓴다.
+type quoteClaims struct {
+	Version      int          `json:"v"`
+	UserID       int64        `json:"uid"`
+	Currency     string       `json:"cur"`
+	BaseCurrency string       `json:"base"`
+	Rate         int64        `json:"rate"`
+	Prices       []quotePrice `json:"prices"`
+	ExpiresAt    int64        `json:"exp"`
+}
+
+type quotePrice struct {
+	ProductID int64        `json:"p"`
+	Price     money.Amount `json:"u"`
+}
+
+func (u *OrderUsecase) quoteTTL() time.Duration {
+	if u.QuoteConfig.TTL > 0 {
+		return u.QuoteConfig.TTL
+	}
+	return defaultQuoteTTL
+}
+
+// Quote 체크아웃과 같은 검증/금액 계산을 저장 없이 수행하고, 계산에 쓴 단가를 서명한 견적 토큰을 돌려준다.
+// 요청 단가는 무시하고 현재 가격으로 채운다.
+func (u *OrderUsecase) Quote(ctx context.Context, checkoutRequest *models.CheckoutRequest) (*models.QuoteResponse, error) {
+	checkoutCurrency, err := u.resolveCheckoutCurrency(ctx, checkoutRequest.Currency)
+	if err != nil {
+		return nil, err
+	}
+	checkoutRequest.Currency = checkoutCurrency.Currency
+
+	productIDs := make([]int64, 0, len(checkoutRequest.Items))
+	for _, item := range checkoutRequest.Items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	// 조회에 실패한 상품은 가격 없이 두고 priceCheckout 검증이 문제 상품으로 모아 알리게 한다.
+	products, _ := u.OrderService.GetProductInfos(ctx, productIDs)
+	for i, item := range checkoutRequest.Items {
+		if product, ok := products[item.ProductID]; ok {
+			checkoutRequest.Items[i].Price = checkoutCurrency.unitPrice(product)
+		}
+	}
+
+	pricing, err := u.priceCheckout(ctx, checkoutRequest, checkoutCurrency, pricingOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := u.now().Add(u.quoteTTL()).Truncate(time.Second)
+	claims := quoteClaims{
+		Version:      quoteVersion,
+		UserID:       checkoutRequest.UserID,
+		Currency:     checkoutCurrency.Currency,
+		BaseCurrency: checkoutCurrency.BaseCurrency,
+		Rate:         int64(checkoutCurrency.Rate),
+		ExpiresAt:    expiresAt.Unix(),
+	}
+	response := &models.QuoteResponse{
+		ExpiresAt:      expiresAt,
+		Currency:       checkoutCurrency.Currency,
+		Items:          make([]models.QuoteItem, 0, len(checkoutRequest.Items)),
+		Subtotal:       pricing.Subtotal,
+		DiscountAmount: pricing.DiscountAmount,
+		Discounts:      pricing.Discounts,
+		TaxAmount:      pricing.TaxAmount,
+		TaxLines:       pricing.TaxLines,
+		ShippingFee:    pricing.ShippingFee,
+		TotalAmount:    pricing.TotalAmount,
+	}
+	for _, item := range checkoutRequest.Items {
+		claims.Prices = append(claims.Prices, quotePrice{ProductID: item.ProductID, Price: item.Price})
+		response.Items = append(response.Items, models.QuoteItem{
+			ProductID:   item.ProductID,
+			ProductName: pricing.ProductInfos[item.ProductID].Name,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			LineTotal:   item.Price.Mul(item.Quantity),
+		})
+	}
+
+	response.QuoteToken, err = u.signQuote(claims)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// resolveCheckoutQuote 견적 토큰이 있으면 토큰의 환율 스냅샷과 단가를, 없으면 현재 환율을 쓴다.
+// 가격을 비워 보낸 상품은 견적 단가로 채운다.
+func (u *OrderUsecase) resolveCheckoutQuote(ctx context.Context, checkoutRequest *models.CheckoutRequest) (checkoutCurrency, pricingOptions, error) {
+	if checkoutRequest.QuoteToken == "" {
+		currency, err := u.resolveCheckoutCurrency(ctx, checkoutRequest.Currency)
+		return currency, pricingOptions{}, err
+	}
+
+	claims, err := u.parseQuote(checkoutRequest.QuoteToken)
+	if err != nil {
+		return checkoutCurrency{}, pricingOptions{}, err
+	}
+	if claims.UserID != checkoutRequest.UserID {
+		return checkoutCurrency{}, pricingOptions{}, fmt.Errorf("%w: issued to another user", ErrInvalidQuote)
+	}
+	if claims.BaseCurrency != u.ExchangeRates.Base() {
+		return checkoutCurrency{}, pricingOptions{}, fmt.Errorf("%w: base currency changed", ErrInvalidQuote)
+	}
+	if requested := strings.ToUpper(checkoutRequest.Currency); requested != "" && requested != claims.Currency {
+		return checkoutCurrency{}, pricingOptions{}, fmt.Errorf("%w: quoted in %s", ErrInvalidQuote, claims.Currency)
+	}
+
+	quoted := make(map[int64]money.Amount, len(claims.Prices))
+	for _, price := range claims.Prices {
+		quoted[price.ProductID] = price.Price
+	}
+	for i, item := range checkoutRequest.Items {
+		if price, ok := quoted[item.ProductID]; ok && item.Price == 0 {
+			checkoutRequest.Items[i].Price = price
+		}
+	}
+	currency := checkoutCurrency{Currency: claims.Currency, BaseCurrency: claims.BaseCurrency, Rate: money.Rate(claims.Rate)}
+	return currency, pricingOptions{Quoted: quoted}, nil
+}
+
+// signQuote base64url(JSON) + "." + base64url(HMAC-SHA256)
+func (u *OrderUsecase) signQuote(claims quoteClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(u.quoteSignature(encoded)), nil
+}
+
+func (u *OrderUsecase) parseQuote(token string) (*quoteClaims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidQuote
+	}
+	expected, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, u.quoteSignature(encoded)) {
+		return nil, ErrInvalidQuote
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidQuote
+	}
+	var claims quoteClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Version != quoteVersion {
+		return nil, ErrInvalidQuote
+	}
+	if u.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrQuoteExpired
+	}
+	return &claims, nil
+}
+
+func (u *OrderUsecase) quoteSignature(encoded string) []byte {
+	mac := hmac.New(sha256.New, []byte(u.QuoteConfig.Secret))
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
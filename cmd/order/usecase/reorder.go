@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/cmd/order/repository"
+	"orderfc/models"
+)
+
+var ErrNothingToReorder = errors.New("none of the items in the order are available")
+
+// Reorder 지난 주문의 상품으로 현재 가격/재고 기준 체크아웃 미리보기를 만든다. place_order면 주문 가능한 상품만으로
+// 바로 주문한다 (품절/삭제 상품과 가격 변경 여부는 응답 플래그로 알린다).
+func (u *OrderUsecase) Reorder(ctx context.Context, userID, orderID int64, req models.ReorderRequest) (*models.ReorderPreview, error) {
+	order, err := u.getOwnedOrder(ctx, userID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	previousItems, err := u.OrderService.GetOrderProducts(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	checkoutCurrency, err := u.resolveCheckoutCurrency(ctx, order.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &models.ReorderPreview{
+		SourceOrderID: order.ID,
+		Currency:      checkoutCurrency.Currency,
+		Items:         make([]models.ReorderItem, 0, len(previousItems)),
+		Checkout: models.CheckoutRequest{
+			PaymentMethod:    order.PaymentMethod,
+			ShippingAddress:  orderShippingAddress(order),
+			Currency:         checkoutCurrency.Currency,
+			CouponCodes:      req.CouponCodes,
+			IdempotencyToken: req.IdempotencyToken,
+		},
+	}
+	if req.PaymentMethod != "" {
+		preview.Checkout.PaymentMethod = req.PaymentMethod
+	}
+	if req.ShippingAddress != nil {
+		preview.Checkout.ShippingAddress = *req.ShippingAddress
+	}
+
+	productIDs := make([]int64, 0, len(previousItems))
+	for _, previous := range previousItems {
+		productIDs = append(productIDs, previous.ProductID)
+	}
+	products, lookupErrs := u.OrderService.GetProductInfos(ctx, productIDs)
+
+	for _, previous := range previousItems {
+		item := models.ReorderItem{
+			ProductID:     previous.ProductID,
+			Quantity:      previous.Quantity,
+			PreviousPrice: previous.Price,
+		}
+		product := products[previous.ProductID]
+		switch err := lookupErrs[previous.ProductID]; {
+		case errors.Is(err, repository.ErrProductNotFound):
+			item.Unavailable = models.ReorderUnavailableNotFound
+		case err != nil:
+			return nil, err
+		default:
+			item.ProductName = product.Name
+			item.CurrentPrice = checkoutCurrency.unitPrice(product)
+			item.PriceChanged = item.CurrentPrice != previous.Price
+			if product.Stock < previous.Quantity {
+				item.Unavailable = models.ReorderUnavailableOutOfStock
+			}
+		}
+		item.Available = item.Unavailable == ""
+
+		if item.Available {
+			preview.Checkout.Items = append(preview.Checkout.Items, models.CheckoutItem{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+				Price:     item.CurrentPrice,
+			})
+		} else {
+			preview.HasUnavailableItems = true
+		}
+		if item.PriceChanged {
+			preview.HasPriceChanges = true
+		}
+		preview.Items = append(preview.Items, item)
+	}
+
+	if len(preview.Checkout.Items) > 0 {
+		// 체크아웃과 같은 파이프라인(쿠폰, 세금, 배송비)으로 계산해 미리보기 금액이 실제 주문 금액과 같게 한다.
+		pricingRequest := preview.Checkout
+		pricingRequest.UserID = userID
+		pricingRequest.Items = append([]models.CheckoutItem(nil), preview.Checkout.Items...)
+		pricing, err := u.priceCheckout(ctx, &pricingRequest, checkoutCurrency, pricingOptions{})
+		if err != nil {
+			return nil, err
+		}
+		preview.Checkout.ShippingAddress = pricingRequest.ShippingAddress
+		preview.Subtotal = pricing.Subtotal
+		preview.DiscountAmount = pricing.DiscountAmount
+		preview.Discounts = pricing.Discounts
+		preview.TaxAmount = pricing.TaxAmount
+		preview.TaxLines = pricing.TaxLines
+		preview.ShippingFee = pricing.ShippingFee
+		preview.TotalAmount = pricing.TotalAmount
+	}
+
+	if !req.PlaceOrder {
+		return preview, nil
+	}
+	if len(preview.Checkout.Items) == 0 {
+		return nil, ErrNothingToReorder
+	}
+	checkoutRequest := preview.Checkout
+	checkoutRequest.UserID = userID
+	preview.OrderID, err = u.CheckOutOrder(ctx, &checkoutRequest)
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+// orderShippingAddress 주문의 배송지를 체크아웃 요청 형태로 돌려준다. 구조화 이전 주문은 한 줄 주소만 있어 문자열 주소로 넘긴다.
+func orderShippingAddress(order *models.Order) models.ShippingAddress {
+	if order.Shipping.IsZero() && order.ShippingAddress != "" {
+		return models.ShippingAddress{Text: order.ShippingAddress}
+	}
+	return order.Shipping
+}
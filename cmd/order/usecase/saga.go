@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/models"
+
+	"gorm.io/gorm"
+)
+
+// GetOrderSaga 관리자용 saga 상태 조회.
+func (u *OrderUsecase) GetOrderSaga(ctx context.Context, orderID int64) (*models.OrderSaga, error) {
+	sg, err := u.OrderService.GetOrderSaga(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return sg, nil
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"orderfc/productclient"
+	"orderfc/saga"
+	"testing"
+)
+
+func checkoutOne(t *testing.T, token string) (*OrderUsecase, *memoryStore, int64) {
+	t.Helper()
+	catalog := productclient.NewFakeCatalog(models.Product{ID: 1, Name: "키보드", Price: 10000, Stock: 5})
+	u, store := newCheckoutUsecase(t, catalog)
+	orderID, err := u.CheckOutOrder(context.Background(), checkoutRequest(token, models.CheckoutItem{ProductID: 1, Quantity: 1, Price: 10000}))
+	if err != nil {
+		t.Fatalf("CheckOutOrder: %v", err)
+	}
+	return u, store, orderID
+}
+
+func countTopic(store *memoryStore, topic string) int {
+	n := 0
+	for _, event := range store.outbox {
+		if event.Topic == topic {
+			n++
+		}
+	}
+	return n
+}
+
+// 고객 취소 뒤 결제 완료가 도착하면 환불을 요청하고, 같은 이벤트의 재수신은 환불을 다시 요청하지 않는다.
+func TestPaymentSucceededAfterCancelRequestsRefund(t *testing.T) {
+	ctx := context.Background()
+	u, store, orderID := checkoutOne(t, "late-payment")
+	if err := u.CancelOrder(ctx, 7, orderID, ""); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	err := u.OrderService.HandlePaymentSucceeded(ctx, orderID)
+	if !errors.Is(err, service.ErrLatePaymentRefunded) {
+		t.Fatalf("err = %v, want ErrLatePaymentRefunded", err)
+	}
+	if n := countTopic(store, "payment.refund_requested"); n != 1 {
+		t.Fatalf("refund events = %d, want 1 (outbox %v)", n, store.topics())
+	}
+	if sg := store.sagas[orderID]; sg.Status != models.SagaStatusCompensated {
+		t.Errorf("saga status = %s, want compensated", sg.Status)
+	}
+	if status := store.orders[orderID].Status; status != constant.OrderStatusCancelled {
+		t.Errorf("order status = %s, want cancelled", constant.OrderStatusMap[status])
+	}
+
+	if err := u.OrderService.HandlePaymentSucceeded(ctx, orderID); !errors.Is(err, saga.ErrSagaFinished) {
+		t.Fatalf("duplicate err = %v, want ErrSagaFinished", err)
+	}
+	if n := countTopic(store, "payment.refund_requested"); n != 1 {
+		t.Errorf("refund events after duplicate = %d, want 1", n)
+	}
+}
+
+// 재고 예약 응답 전에 취소하면 잡히지 않은 재고를 되돌리지 않고, 늦게 도착한 stock.reserved가 한 번만 해제한다.
+func TestCancelBeforeReservationReleasesOnLateStockReserved(t *testing.T) {
+	ctx := context.Background()
+	u, store, orderID := checkoutOne(t, "cancel-before-reserve")
+	if err := u.CancelOrder(ctx, 7, orderID, ""); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if n := countTopic(store, "stock.rollback"); n != 0 {
+		t.Fatalf("stock.rollback before reservation = %d, want 0", n)
+	}
+
+	if err := u.OrderService.HandleStockReserved(ctx, stockReserved(orderID, "13000")); err != nil {
+		t.Fatalf("late HandleStockReserved: %v", err)
+	}
+	if n := countTopic(store, "stock.rollback"); n != 1 {
+		t.Fatalf("stock.rollback after late reservation = %d, want 1 (outbox %v)", n, store.topics())
+	}
+	if sg := store.sagas[orderID]; sg.Status != models.SagaStatusCompensated {
+		t.Errorf("saga status = %s, want compensated", sg.Status)
+	}
+
+	if err := u.OrderService.HandleStockReserved(ctx, stockReserved(orderID, "13000")); !errors.Is(err, saga.ErrSagaFinished) {
+		t.Fatalf("duplicate err = %v, want ErrSagaFinished", err)
+	}
+	if n := countTopic(store, "stock.rollback"); n != 1 {
+		t.Errorf("stock.rollback after duplicate = %d, want 1", n)
+	}
+}
+
+func TestCancelAfterReservationReleasesStock(t *testing.T) {
+	ctx := context.Background()
+	u, store, orderID := checkoutOne(t, "cancel-after-reserve")
+	if err := u.OrderService.HandleStockReserved(ctx, stockReserved(orderID, "13000")); err != nil {
+		t.Fatalf("HandleStockReserved: %v", err)
+	}
+	if err := u.CancelOrder(ctx, 7, orderID, ""); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if n := countTopic(store, "stock.rollback"); n != 1 {
+		t.Fatalf("stock.rollback = %d, want 1 (outbox %v)", n, store.topics())
+	}
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/models"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidSearchQuery = errors.New("q must contain at least one letter or digit")
+
+const maxSearchTerms = 8
+
+// FullTextSearchOrders 관리자 전문 검색. 검색어의 각 단어를 접두사로 AND 검색해 주소/상품명 일부만으로도 찾는다.
+func (u *OrderUsecase) FullTextSearchOrders(ctx context.Context, params models.OrderSearchParam) (*models.OrderSearchPage, error) {
+	tsQuery := prefixTSQuery(params.Query)
+	if tsQuery == "" {
+		return nil, ErrInvalidSearchQuery
+	}
+	if params.Limit <= 0 {
+		params.Limit = defaultOrderHistoryLimit
+	}
+	if params.Limit > maxOrderHistoryLimit {
+		params.Limit = maxOrderHistoryLimit
+	}
+	if params.Offset < 0 {
+		params.Offset = 0
+	}
+
+	// 다음 페이지 존재 여부 확인을 위해 한 건 더 조회한다.
+	query := params
+	query.Limit = params.Limit + 1
+	results, err := u.OrderService.FullTextSearchOrders(ctx, tsQuery, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.OrderSearchPage{Results: results}
+	if len(results) > params.Limit {
+		page.Results = results[:params.Limit]
+		nextOffset := params.Offset + params.Limit
+		page.NextOffset = &nextOffset
+	}
+	if page.Results == nil {
+		page.Results = []models.OrderSearchResult{}
+	}
+	return page, nil
+}
+
+// prefixTSQuery 사용자 입력을 to_tsquery 문법("서울:* & 강남:*")으로 바꾼다. 문자/숫자 외에는 구분자로 보고
+// 버리므로 tsquery 연산자가 입력에 섞여도 문법 오류가 나지 않는다.
+func prefixTSQuery(q string) string {
+	terms := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrInvalidShipment = errors.New("carrier and tracking_number are required")
+
+// ShipOrder 관리자 출고 처리. 결제 완료(completed) 주문만 shipped로 전이된다.
+func (u *OrderUsecase) ShipOrder(ctx context.Context, orderID int64, req models.ShipOrderRequest) error {
+	carrier := strings.TrimSpace(req.Carrier)
+	trackingNumber := strings.TrimSpace(req.TrackingNumber)
+	if carrier == "" || trackingNumber == "" {
+		return ErrInvalidShipment
+	}
+	shippedAt := time.Now()
+	if req.ShippedAt != nil {
+		shippedAt = *req.ShippedAt
+	}
+
+	err := u.OrderService.ShipOrder(ctx, &models.Shipment{
+		OrderID:        orderID,
+		Carrier:        carrier,
+		TrackingNumber: trackingNumber,
+		ShippedAt:      &shippedAt,
+	}, constant.OrderActorAdmin)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrOrderNotFound
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"orderfc/shipping"
+	"strings"
+)
+
+const (
+	minPhoneDigits = 7
+	maxPhoneDigits = 15
+)
+
+// normalizeShippingAddress 공백을 정리하고 국가 코드를 대문자로 맞춘 뒤 필수 항목을 검증한다.
+func normalizeShippingAddress(address *models.ShippingAddress) error {
+	address.Recipient = strings.TrimSpace(address.Recipient)
+	address.Phone = strings.TrimSpace(address.Phone)
+	address.Line1 = strings.TrimSpace(address.Line1)
+	address.Line2 = strings.TrimSpace(address.Line2)
+	address.City = strings.TrimSpace(address.City)
+	address.PostalCode = strings.TrimSpace(address.PostalCode)
+	address.Country = strings.ToUpper(strings.TrimSpace(address.Country))
+	address.Text = strings.TrimSpace(address.Text)
+	if address.IsLegacy() {
+		// 문자열 주소는 국가를 알 수 없어 세금/배송비 모두 tax.default_region(국내) 기준으로 계산한다.
+		return nil
+	}
+	address.Text = ""
+
+	required := []struct {
+		field string
+		value string
+	}{
+		{"recipient", address.Recipient},
+		{"phone", address.Phone},
+		{"line1", address.Line1},
+		{"city", address.City},
+		{"postal_code", address.PostalCode},
+		{"country", address.Country},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			return fmt.Errorf("%w: %s is required", ErrInvalidShippingAddress, r.field)
+		}
+	}
+
+	if len(address.Country) != 2 || strings.Trim(address.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return fmt.Errorf("%w: country must be an ISO 3166-1 alpha-2 code", ErrInvalidShippingAddress)
+	}
+	if len(address.PostalCode) > 20 {
+		return fmt.Errorf("%w: postal_code is too long", ErrInvalidShippingAddress)
+	}
+
+	digits := 0
+	for i, r := range address.Phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '+' && i == 0, r == '-', r == ' ', r == '(', r == ')':
+		default:
+			return fmt.Errorf("%w: phone contains invalid characters", ErrInvalidShippingAddress)
+		}
+	}
+	if digits < minPhoneDigits || digits > maxPhoneDigits {
+		return fmt.Errorf("%w: phone must have %d-%d digits", ErrInvalidShippingAddress, minPhoneDigits, maxPhoneDigits)
+	}
+	return nil
+}
+
+// calculateShippingFee 배송 요금은 기준 통화로 설정되어 있어 할인 후 상품 금액을 기준 통화로 환산해 계산하고,
+// 결과를 다시 주문 통화로 변환한다.
+func (u *OrderUsecase) calculateShippingFee(ctx context.Context, items []models.CheckoutItem, productInfos map[int64]models.Product, netSubtotal money.Amount, country string, checkoutCurrency checkoutCurrency) (money.Amount, error) {
+	weightGrams := 0
+	for _, item := range items {
+		weightGrams += productInfos[item.ProductID].Weight * item.Quantity
+	}
+
+	baseFee, err := u.ShippingCalculator.Calculate(ctx, shipping.Input{
+		Country:     country,
+		Subtotal:    money.Convert(netSubtotal, checkoutCurrency.Currency, checkoutCurrency.BaseCurrency, checkoutCurrency.Rate.Inverse()),
+		WeightGrams: weightGrams,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return money.Convert(baseFee, checkoutCurrency.BaseCurrency, checkoutCurrency.Currency, checkoutCurrency.Rate), nil
+}
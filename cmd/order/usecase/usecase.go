@@ -3,70 +3,187 @@ package usecase
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"orderfc/cmd/order/service"
+	"orderfc/config"
 	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/exchangerate"
+	"orderfc/infrastructure/money"
 	"orderfc/kafka"
 	"orderfc/models"
+	"orderfc/shipping"
+	"orderfc/tax"
+	"strings"
 	"time"
+
+	"gorm.io/gorm"
 )
 
 var (
 	ErrIdempotencyInProgress   = errors.New("idempotency request is still processing")
 	ErrIdempotencyKeyReused    = errors.New("idempotency token reused with different request")
 	ErrIdempotencyPreviousFail = errors.New("idempotency request previously failed")
+	ErrOrderNotFound           = errors.New("order not found")
+	ErrOrderAccessDenied       = errors.New("order does not belong to user")
+	ErrInvalidCursor           = errors.New("invalid cursor")
+	ErrInvalidSort             = errors.New("sort must be asc or desc")
+	ErrUnsupportedCurrency     = errors.New("unsupported currency")
+	ErrInvalidShippingAddress  = errors.New("invalid shipping address")
+	ErrCheckoutValidation      = errors.New("checkout validation failed")
+	ErrInvalidQuote            = errors.New("invalid quote token")
+	ErrQuoteExpired            = errors.New("quote has expired")
+)
+
+const (
+	defaultOrderHistoryLimit = 20
+	maxOrderHistoryLimit     = 100
 )
 
 type OrderUsecase struct {
-	OrderService  service.OrderService
-	KafkaProducer *kafka.KafkaProducer
+	OrderService       service.OrderService
+	KafkaProducer      *kafka.KafkaProducer
+	ExchangeRates      exchangerate.Provider
+	TaxCalculator      tax.TaxCalculator
+	ShippingCalculator shipping.ShippingCalculator
+	CartConfig         config.CartConfig
+	QuoteConfig        config.QuoteConfig
+
+	now func() time.Time // 견적 토큰 만료 시각 기준. 테스트에서 바꿔 끼운다.
 }
 
-func NewOrderUsecase(orderService service.OrderService, kafkaProducer *kafka.KafkaProducer) *OrderUsecase {
-	return &OrderUsecase{OrderService: orderService, KafkaProducer: kafkaProducer}
+func NewOrderUsecase(orderService service.OrderService, kafkaProducer *kafka.KafkaProducer, exchangeRates exchangerate.Provider, taxCalculator tax.TaxCalculator, shippingCalculator shipping.ShippingCalculator, cartConfig config.CartConfig, quoteConfig config.QuoteConfig) *OrderUsecase {
+	return &OrderUsecase{
+		OrderService:       orderService,
+		KafkaProducer:      kafkaProducer,
+		ExchangeRates:      exchangeRates,
+		TaxCalculator:      taxCalculator,
+		ShippingCalculator: shippingCalculator,
+		CartConfig:         cartConfig,
+		QuoteConfig:        quoteConfig,
+		now:                time.Now,
+	}
 }
 
-func (u *OrderUsecase) CheckOutOrder(ctx context.Context, checkoutRequest *models.CheckoutRequest) (int64, error) {
-	err := u.validateProducts(ctx, checkoutRequest.Items)
+// checkoutCurrency 주문 통화와 체크아웃 시점 환율 스냅샷 (기준 통화 -> 주문 통화).
+type checkoutCurrency struct {
+	Currency     string
+	BaseCurrency string
+	Rate         money.Rate
+}
+
+func (u *OrderUsecase) resolveCheckoutCurrency(ctx context.Context, requested string) (checkoutCurrency, error) {
+	base := u.ExchangeRates.Base()
+	currency := strings.ToUpper(requested)
+	if currency == "" {
+		currency = base
+	}
+	if !money.IsSupported(currency) {
+		return checkoutCurrency{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	rate, err := u.ExchangeRates.Rate(ctx, base, currency)
 	if err != nil {
-		return 0, err
+		if errors.Is(err, exchangerate.ErrRateNotFound) {
+			return checkoutCurrency{}, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+		}
+		return checkoutCurrency{}, err
 	}
+	return checkoutCurrency{Currency: currency, BaseCurrency: base, Rate: rate}, nil
+}
+
+// basePrice productfc 가격(기준 통화 major unit)을 minor unit으로 변환한다.
+func (c checkoutCurrency) basePrice(product models.Product) money.Amount {
+	return money.FromMajor(product.Price, c.BaseCurrency)
+}
 
+// unitPrice 주문 통화 단가. 클라이언트가 보낸 가격은 이 값과 정확히 일치해야 한다.
+func (c checkoutCurrency) unitPrice(product models.Product) money.Amount {
+	return money.Convert(c.basePrice(product), c.BaseCurrency, c.Currency, c.Rate)
+}
+
+// CheckOutOrder 멱등 키가 있으면 가격/쿠폰 평가 전에 먼저 예약하고 이전 결과를 재생한다.
+// 재시도 시 이미 사용한 쿠폰이 사용 한도에 잡히거나 견적이 만료되어도 같은 주문 ID를 돌려받는다.
+func (u *OrderUsecase) CheckOutOrder(ctx context.Context, checkoutRequest *models.CheckoutRequest) (int64, error) {
 	if checkoutRequest.IdempotencyToken != "" {
-		requestHash, err := hashCheckoutRequest(checkoutRequest)
-		if err != nil {
-			return 0, err
+		orderID, err := u.reserveCheckoutIdempotency(ctx, checkoutRequest)
+		if err != nil || orderID > 0 {
+			return orderID, err
 		}
-		idem, reserved, err := u.OrderService.ReserveIdempotencyToken(ctx, checkoutRequest.IdempotencyToken, requestHash)
-		if err != nil {
-			return 0, err
+	}
+
+	var (
+		orderID int64
+		saved   bool
+		err     error
+	)
+	for attempt := 0; attempt < maxPromotionRepricing; attempt++ {
+		orderID, saved, err = u.placeOrder(ctx, checkoutRequest)
+		if !errors.Is(err, service.ErrAutoPromotionExhausted) {
+			break
 		}
-		if !reserved {
-			if idem.RequestHash != requestHash {
-				return 0, ErrIdempotencyKeyReused
-			}
-			switch idem.Status {
-			case models.IdempotencyStatusSucceeded:
-				if idem.OrderID > 0 {
-					return idem.OrderID, nil
-				}
-				return 0, ErrIdempotencyInProgress
-			case models.IdempotencyStatusProcessing:
-				return 0, ErrIdempotencyInProgress
-			case models.IdempotencyStatusFailed:
-				return 0, ErrIdempotencyPreviousFail
-			default:
-				return 0, ErrIdempotencyInProgress
-			}
+	}
+	if err != nil && checkoutRequest.IdempotencyToken != "" {
+		if saved {
+			_ = u.OrderService.MarkIdempotencyTokenFailed(ctx, checkoutRequest.IdempotencyToken, err)
+		} else {
+			// 주문 저장 전 실패(검증/쿠폰/견적)는 아무것도 남기지 않았으므로 예약을 풀어 재시도를 허용한다.
+			_ = u.OrderService.ReleaseIdempotencyToken(ctx, checkoutRequest.IdempotencyToken)
+		}
+	}
+	return orderID, err
+}
+
+// reserveCheckoutIdempotency 키를 예약한다. 이미 처리된 요청이면 그 주문 ID를, 새로 예약했으면 0을 반환한다.
+func (u *OrderUsecase) reserveCheckoutIdempotency(ctx context.Context, checkoutRequest *models.CheckoutRequest) (int64, error) {
+	requestHash, err := hashCheckoutRequest(checkoutRequest)
+	if err != nil {
+		return 0, err
+	}
+	idem, reserved, err := u.OrderService.ReserveIdempotencyToken(ctx, checkoutRequest.IdempotencyToken, requestHash)
+	if err != nil {
+		return 0, err
+	}
+	if reserved {
+		return 0, nil
+	}
+	if idem.RequestHash != requestHash {
+		return 0, ErrIdempotencyKeyReused
+	}
+	switch idem.Status {
+	case models.IdempotencyStatusSucceeded:
+		if idem.OrderID > 0 {
+			return idem.OrderID, nil
 		}
+		return 0, ErrIdempotencyInProgress
+	case models.IdempotencyStatusProcessing:
+		return 0, ErrIdempotencyInProgress
+	case models.IdempotencyStatusFailed:
+		return 0, ErrIdempotencyPreviousFail
+	default:
+		return 0, ErrIdempotencyInProgress
+	}
+}
+
+// placeOrder 가격을 계산하고 주문을 저장한다. saved는 저장 트랜잭션까지 진행했는지 여부.
+func (u *OrderUsecase) placeOrder(ctx context.Context, checkoutRequest *models.CheckoutRequest) (int64, bool, error) {
+	checkoutCurrency, opts, err := u.resolveCheckoutQuote(ctx, checkoutRequest)
+	if err != nil {
+		return 0, false, err
 	}
+	checkoutRequest.Currency = checkoutCurrency.Currency
+	currency := checkoutCurrency.Currency
 
-	totalQty, totalAmount := u.calculateItemSummary(ctx, checkoutRequest.Items)
+	pricing, err := u.priceCheckout(ctx, checkoutRequest, checkoutCurrency, opts)
+	if err != nil {
+		return 0, false, err
+	}
+	shippingAddress := checkoutRequest.ShippingAddress
 
 	products, history := u.constructOrderDetail(ctx, checkoutRequest.Items)
+	orderItems := buildOrderItems(checkoutRequest.Items, pricing.ProductInfos, currency)
 
 	orderDetail := &models.OrderDetail{
 		Products:     products,
@@ -75,20 +192,32 @@ func (u *OrderUsecase) CheckOutOrder(ctx context.Context, checkoutRequest *model
 
 	order := &models.Order{
 		UserID:          checkoutRequest.UserID,
-		Amount:          totalAmount,
-		TotalQty:        totalQty,
 		PaymentMethod:   checkoutRequest.PaymentMethod,
-		ShippingAddress: checkoutRequest.ShippingAddress,
+		ShippingAddress: shippingAddress.Format(),
+		Shipping:        shippingAddress,
 		Status:          constant.OrderStatusCreated,
 	}
+	pricing.apply(order)
+	order.ReservationAmount = order.Amount
 
-	orderId, err := u.OrderService.SaveOrderAndOrderDetailWithOutboxAndIdempotency(ctx, order, orderDetail, checkoutRequest.IdempotencyToken, func(orderID int64) ([]models.OrderOutboxEvent, error) {
+	orderId, err := u.OrderService.SaveOrderAndOrderDetailWithOutboxAndIdempotency(ctx, order, orderDetail, service.OrderComponents{
+		Items:     orderItems,
+		Discounts: pricing.Discounts,
+		TaxLines:  pricing.TaxLines,
+	}, checkoutRequest.IdempotencyToken, func(orderID int64) ([]models.OrderOutboxEvent, error) {
 		orderCreatedEvent := models.OrderCreatedEvent{
+			SchemaVersion:   models.OrderCreatedSchemaVersion,
 			OrderID:         orderID,
 			UserID:          checkoutRequest.UserID,
-			TotalAmount:     totalAmount,
+			TotalAmount:     pricing.TotalAmount,
+			Currency:        currency,
+			DiscountAmount:  pricing.DiscountAmount,
+			Discounts:       pricing.Discounts,
+			TaxAmount:       pricing.TaxAmount,
+			ShippingFee:     pricing.ShippingFee,
 			PaymentMethod:   checkoutRequest.PaymentMethod,
-			ShippingAddress: checkoutRequest.ShippingAddress,
+			ShippingAddress: shippingAddress.Format(),
+			Shipping:        shippingAddress,
 			Products:        convertCheckoutItemToProductItem(checkoutRequest.Items),
 		}
 		orderCreatedPayload, err := json.Marshal(orderCreatedEvent)
@@ -108,46 +237,26 @@ func (u *OrderUsecase) CheckOutOrder(ctx context.Context, checkoutRequest *model
 		}, nil
 	})
 	if err != nil {
-		if checkoutRequest.IdempotencyToken != "" {
-			_ = u.OrderService.MarkIdempotencyTokenFailed(ctx, checkoutRequest.IdempotencyToken, err)
-		}
-		return 0, err
+		return 0, true, err
 	}
-	return orderId, nil
-
+	return orderId, true, nil
 }
 
-func (u *OrderUsecase) validateProducts(ctx context.Context, items []models.CheckoutItem) error {
-
+func buildOrderItems(items []models.CheckoutItem, productInfos map[int64]models.Product, currency string) []models.OrderItem {
+	orderItems := make([]models.OrderItem, 0, len(items))
 	for _, item := range items {
-		productInfo, err := u.OrderService.GetProductInfo(ctx, item.ProductID)
-		if err != nil {
-			return err
-		}
-
-		if productInfo.Stock < item.Quantity {
-			return fmt.Errorf("product stock is not enough for product %d", item.ProductID)
-		}
-
-		if item.Quantity <= 0 || item.Quantity > 1000 {
-			return fmt.Errorf("quantity must be between 1 and 1000 for product %d", item.ProductID)
-		}
-
-		if item.Price != productInfo.Price {
-			return fmt.Errorf("price mismatch for product %d", item.ProductID)
-		}
-	}
-	return nil
-}
-
-func (u *OrderUsecase) calculateItemSummary(ctx context.Context, items []models.CheckoutItem) (int, float64) {
-	var totalQty int
-	var totalAmount float64
-	for _, item := range items {
-		totalAmount += item.Price * float64(item.Quantity)
-		totalQty += item.Quantity
+		productInfo := productInfos[item.ProductID]
+		orderItems = append(orderItems, models.OrderItem{
+			ProductID:   item.ProductID,
+			Quantity:    item.Quantity,
+			Currency:    currency,
+			UnitPrice:   item.Price,
+			LineTotal:   item.Price.Mul(item.Quantity),
+			ProductName: productInfo.Name,
+			ProductSKU:  productInfo.SKU,
+		})
 	}
-	return totalQty, totalAmount
+	return orderItems
 }
 
 func (u *OrderUsecase) constructOrderDetail(ctx context.Context, items []models.CheckoutItem) (string, string) {
@@ -155,10 +264,11 @@ func (u *OrderUsecase) constructOrderDetail(ctx context.Context, items []models.
 	if err != nil {
 		return "", ""
 	}
-	history := []map[string]interface{}{
+	history := []models.StatusHistory{
 		{
-			"status": constant.OrderStatusCreated,
-			"time":   time.Now(),
+			Status:    constant.OrderStatusCreated,
+			Timestamp: time.Now().Format(time.RFC3339),
+			Actor:     constant.OrderActorUser,
 		},
 	}
 	historyJson, err := json.Marshal(history)
@@ -168,12 +278,126 @@ func (u *OrderUsecase) constructOrderDetail(ctx context.Context, items []models.
 	return string(productJson), string(historyJson)
 }
 
-func (u *OrderUsecase) GetOrderHistoryByUserId(ctx context.Context, params models.OrderHistoryParam) ([]models.OrderHistoryResponse, error) {
-	results, err := u.OrderService.GetOrderHistoryByUserId(ctx, params)
+// CancelOrder 고객 요청 취소. 소유자 확인 후 취소 전이와 stock.rollback outbox 적재를 같은 트랜잭션에서 처리한다.
+// 재고 예약이 확인되기 전이면 stock.rollback 대신 늦게 도착한 stock.reserved가 재고를 해제한다.
+func (u *OrderUsecase) CancelOrder(ctx context.Context, userID, orderID int64, reason string) error {
+	order, err := u.getOwnedOrder(ctx, userID, orderID)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		reason = "customer_request"
+	}
+
+	return u.OrderService.TransitionOrderStatusWithOutbox(ctx, order.ID, constant.OrderStatusCancelled, constant.OrderActorUser, reason, func(tx *gorm.DB, order *models.Order) ([]models.OrderOutboxEvent, error) {
+		return u.OrderService.ReservedStockRollbackEventsTx(ctx, tx, order)
+	})
+}
+
+func (u *OrderUsecase) getOwnedOrder(ctx context.Context, userID, orderID int64) (*models.Order, error) {
+	order, err := u.OrderService.GetOrderInfoByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, ErrOrderAccessDenied
+	}
+	return order, nil
+}
+
+func (u *OrderUsecase) GetOrderHistoryByUserId(ctx context.Context, params models.OrderHistoryParam) (*models.OrderHistoryPage, error) {
+	return paginateOrderHistory(ctx, params, u.OrderService.GetOrderHistoryByUserId)
+}
+
+// SearchOrders 관리자 주문 검색. params.UserID가 0이면 모든 사용자의 주문을 조회한다.
+func (u *OrderUsecase) SearchOrders(ctx context.Context, params models.OrderHistoryParam) (*models.OrderHistoryPage, error) {
+	return paginateOrderHistory(ctx, params, u.OrderService.SearchOrders)
+}
+
+func paginateOrderHistory(ctx context.Context, params models.OrderHistoryParam, fetch func(context.Context, models.OrderHistoryParam) ([]models.OrderHistoryResponse, error)) (*models.OrderHistoryPage, error) {
+	if params.Limit <= 0 {
+		params.Limit = defaultOrderHistoryLimit
+	}
+	if params.Limit > maxOrderHistoryLimit {
+		params.Limit = maxOrderHistoryLimit
+	}
+	if params.Sort == "" {
+		params.Sort = models.OrderHistorySortDesc
+	}
+	if params.Sort != models.OrderHistorySortDesc && params.Sort != models.OrderHistorySortAsc {
+		return nil, ErrInvalidSort
+	}
+	if params.Cursor != "" {
+		after, err := decodeOrderHistoryCursor(params.Cursor)
+		if err != nil || after.Sort != params.Sort {
+			return nil, ErrInvalidCursor
+		}
+		params.After = after
+	}
+
+	// 다음 페이지 존재 여부 확인을 위해 한 건 더 조회한다.
+	query := params
+	query.Limit = params.Limit + 1
+	results, err := fetch(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.OrderHistoryPage{Orders: results}
+	if len(results) > params.Limit {
+		page.Orders = results[:params.Limit]
+		last := page.Orders[len(page.Orders)-1]
+		page.NextCursor, err = encodeOrderHistoryCursor(models.OrderHistoryCursor{
+			CreateTime: last.CreateTime,
+			ID:         last.OrderID,
+			Sort:       params.Sort,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if page.Orders == nil {
+		page.Orders = []models.OrderHistoryResponse{}
+	}
+	return page, nil
+}
+
+func encodeOrderHistoryCursor(cursor models.OrderHistoryCursor) (string, error) {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeOrderHistoryCursor(encoded string) (*models.OrderHistoryCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
 	if err != nil {
 		return nil, err
 	}
-	return results, nil
+	var cursor models.OrderHistoryCursor
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
+
+// GetOrderByID 주문 단건 조회. allowAnyUser가 false면 요청자 본인 주문만 반환한다 (관리자 조회용 플래그).
+func (u *OrderUsecase) GetOrderByID(ctx context.Context, requesterID, orderID int64, allowAnyUser bool) (*models.OrderHistoryResponse, error) {
+	result, err := u.OrderService.GetOrderHistoryByOrderID(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if !allowAnyUser && result.UserID != requesterID {
+		return nil, ErrOrderAccessDenied
+	}
+	return result, nil
 }
 
 func (u *OrderUsecase) GetProductInfo(ctx context.Context, productID int64) (models.Product, error) {
@@ -184,11 +408,43 @@ func (u *OrderUsecase) GetProductInfo(ctx context.Context, productID int64) (mod
 	return product, nil
 }
 
-func (u *OrderUsecase) GetDailySalesReport(ctx context.Context, days int) ([]models.DailySalesReport, error) {
+func (u *OrderUsecase) InvalidateProductCache(ctx context.Context, productID int64) {
+	u.OrderService.InvalidateProductCache(ctx, productID)
+}
+
+// GetDailySalesReport currency가 비어 있으면 기준 통화로, 아니면 현재 환율로 환산해 리포트한다.
+func (u *OrderUsecase) GetDailySalesReport(ctx context.Context, days int, currency string) ([]models.DailySalesReport, error) {
 	if days <= 0 {
 		days = 30
 	}
-	return u.OrderService.GetDailySalesReport(ctx, days)
+	base := u.ExchangeRates.Base()
+	currency = strings.ToUpper(currency)
+	if currency == "" {
+		currency = base
+	}
+	if !money.IsSupported(currency) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+	rate, err := u.ExchangeRates.Rate(ctx, base, currency)
+	if err != nil {
+		if errors.Is(err, exchangerate.ErrRateNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+		}
+		return nil, err
+	}
+
+	reports, err := u.OrderService.GetDailySalesReport(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reports {
+		reports[i].Currency = currency
+		reports[i].TotalRevenue = money.Convert(reports[i].TotalRevenue, base, currency, rate)
+		reports[i].TotalTax = money.Convert(reports[i].TotalTax, base, currency, rate)
+		reports[i].AvgOrderValue = money.Convert(reports[i].AvgOrderValue, base, currency, rate)
+		reports[i].CumulativeRevenue = money.Convert(reports[i].CumulativeRevenue, base, currency, rate)
+	}
+	return reports, nil
 }
 
 func convertCheckoutItemToProductItem(items []models.CheckoutItem) []models.ProductItem {
@@ -202,17 +458,24 @@ func convertCheckoutItemToProductItem(items []models.CheckoutItem) []models.Prod
 	return productItems
 }
 
+// hashCheckoutRequest 견적 적용/가격 계산 전의 원본 요청으로 계산해 재시도와 비교한다.
 func hashCheckoutRequest(req *models.CheckoutRequest) (string, error) {
 	payload := struct {
-		UserID          int64                 `json:"user_id"`
-		Items           []models.CheckoutItem `json:"items"`
-		PaymentMethod   string                `json:"payment_method"`
-		ShippingAddress string                `json:"shipping_address"`
+		UserID          int64                  `json:"user_id"`
+		Items           []models.CheckoutItem  `json:"items"`
+		PaymentMethod   string                 `json:"payment_method"`
+		ShippingAddress models.ShippingAddress `json:"shipping_address"`
+		Currency        string                 `json:"currency"`
+		CouponCodes     []string               `json:"coupon_codes"`
+		QuoteToken      string                 `json:"quote_token"`
 	}{
 		UserID:          req.UserID,
 		Items:           req.Items,
 		PaymentMethod:   req.PaymentMethod,
 		ShippingAddress: req.ShippingAddress,
+		Currency:        strings.ToUpper(req.Currency),
+		CouponCodes:     req.CouponCodes,
+		QuoteToken:      req.QuoteToken,
 	}
 	b, err := json.Marshal(payload)
 	if err != nil {
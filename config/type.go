@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type Config struct {
 	App      AppConfig      `yaml:"app" validate:"required"`
 	Database DatabaseConfig `yaml:"database" validate:"required"`
@@ -7,6 +9,62 @@ type Config struct {
 	Kafka    KafkaConfig    `yaml:"kafka" validate:"required"`
 	Product  ProductConfig  `yaml:"product" validate:"required"`
 	Tracing  TracingConfig  `yaml:"tracing"`
+	Currency CurrencyConfig `yaml:"currency"`
+	Tax      TaxConfig      `yaml:"tax"`
+	Shipping ShippingConfig `yaml:"shipping"`
+	Order    OrderConfig    `yaml:"order"`
+	Cart     CartConfig     `yaml:"cart"`
+	Quote    QuoteConfig    `yaml:"quote"`
+}
+
+// QuoteConfig secret은 견적 토큰 HMAC 서명 키로 필수이며 JWT secret과 달라야 한다. ttl 동안 견적 단가가 유지된다 (0이면 10분).
+type QuoteConfig struct {
+	Secret string        `yaml:"secret" mapstructure:"secret"`
+	TTL    time.Duration `yaml:"ttl" mapstructure:"ttl"`
+}
+
+// CartConfig ttl은 마지막 변경 이후 장바구니를 보관하는 기간. 0이면 기본값(7일)을 쓴다.
+type CartConfig struct {
+	TTL time.Duration `yaml:"ttl" mapstructure:"ttl"`
+}
+
+// OrderConfig payment_timeout이 지나도록 created 상태인 주문은 만료 sweeper가 취소한다. 0이면 sweeper를 띄우지 않는다.
+type OrderConfig struct {
+	PaymentTimeout time.Duration `yaml:"payment_timeout" mapstructure:"payment_timeout"`
+	SweepInterval  time.Duration `yaml:"sweep_interval" mapstructure:"sweep_interval"`
+	SweepBatchSize int           `yaml:"sweep_batch_size" mapstructure:"sweep_batch_size"`
+}
+
+// ShippingConfig 금액은 기준 통화 minor unit. countries에 없는 국가는 default 요금을 사용한다.
+type ShippingConfig struct {
+	Default   ShippingRateConfig            `yaml:"default" mapstructure:"default"`
+	Countries map[string]ShippingRateConfig `yaml:"countries" mapstructure:"countries"`
+}
+
+type ShippingRateConfig struct {
+	FlatFee  int64 `yaml:"flat_fee" mapstructure:"flat_fee"`
+	PerKgFee int64 `yaml:"per_kg_fee" mapstructure:"per_kg_fee"` // 총 무게 kg 올림 단위
+	FreeOver int64 `yaml:"free_over" mapstructure:"free_over"`   // 할인 후 상품 금액이 이 이상이면 무료 (0 = 미적용)
+}
+
+// TaxConfig 세율은 basis point (1000 = 10%). category_rates 키는 상품 category_id.
+// inclusive가 true인 지역은 상품 가격에 세금이 포함되어 있어(KR 부가세 등) 가격에서 세금을 역산한다.
+type TaxConfig struct {
+	DefaultRegion string                     `yaml:"default_region" mapstructure:"default_region"`
+	Regions       map[string]TaxRegionConfig `yaml:"regions" mapstructure:"regions"`
+}
+
+type TaxRegionConfig struct {
+	RateBps       int            `yaml:"rate_bps" mapstructure:"rate_bps"`
+	Inclusive     bool           `yaml:"inclusive" mapstructure:"inclusive"`
+	CategoryRates map[string]int `yaml:"category_rates" mapstructure:"category_rates"`
+}
+
+// CurrencyConfig rates는 기준 통화 1단위가 각 통화로 얼마인지 (10진 문자열). rates_file이 있으면 파일이 우선한다.
+type CurrencyConfig struct {
+	Base      string            `yaml:"base" mapstructure:"base"`
+	RatesFile string            `yaml:"rates_file" mapstructure:"rates_file"`
+	Rates     map[string]string `yaml:"rates" mapstructure:"rates"`
 }
 
 type TracingConfig struct {
@@ -19,8 +77,23 @@ type AppConfig struct {
 	Port string `yaml:"port" validate:"required"`
 }
 
+// ProductConfig cache_ttl은 Redis 상품 캐시 보관 기간. 0이면 캐시를 쓰지 않는다.
 type ProductConfig struct {
-	Host string `yaml:"host" validate:"required"`
+	Host             string        `yaml:"host" validate:"required"`
+	Transport        string        `yaml:"transport" mapstructure:"transport"` // http(기본) 또는 grpc
+	GRPCAddr         string        `yaml:"grpc_addr" mapstructure:"grpc_addr"` // transport가 grpc일 때 productfc gRPC 주소 (host:port)
+	CacheTTL         time.Duration `yaml:"cache_ttl" mapstructure:"cache_ttl"`
+	BatchConcurrency int           `yaml:"batch_concurrency" mapstructure:"batch_concurrency"` // 배치 조회 API가 없을 때 개별 조회 동시 요청 수 (기본 8)
+
+	// productfc 클라이언트. 0이면 기본값 (timeout 2s, 재시도 2회, 100ms~1s 지터 백오프, 연속 5회 실패 시 30s 차단).
+	// max_retries/breaker_threshold를 음수로 두면 재시도/브레이커를 끈다. timeout은 시도당 상한이며, 요청 deadline이
+	// 있으면 남은 시간을 남은 시도 수로 나눈 값으로 줄인다.
+	Timeout          time.Duration `yaml:"timeout" mapstructure:"timeout"`
+	MaxRetries       int           `yaml:"max_retries" mapstructure:"max_retries"`
+	RetryBaseDelay   time.Duration `yaml:"retry_base_delay" mapstructure:"retry_base_delay"`
+	RetryMaxDelay    time.Duration `yaml:"retry_max_delay" mapstructure:"retry_max_delay"`
+	BreakerThreshold int           `yaml:"breaker_threshold" mapstructure:"breaker_threshold"`
+	BreakerCooldown  time.Duration `yaml:"breaker_cooldown" mapstructure:"breaker_cooldown"`
 }
 
 type DatabaseConfig struct {
@@ -0,0 +1,75 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+)
+
+// 감사 로그의 행위자 종류
+const (
+	ActorUser     = "user"
+	ActorAdmin    = "admin"
+	ActorConsumer = "consumer"
+	ActorJob      = "job"
+)
+
+// 감사 로그의 변경 출처 종류
+const (
+	SourceHTTP  = "http"
+	SourceKafka = "kafka"
+	SourceJob   = "job"
+)
+
+// Actor 주문을 변경한 주체. ID는 사용자/관리자 ID, consumer 이름 또는 job 이름.
+type Actor struct {
+	Type string
+	ID   string
+}
+
+// Source 변경을 일으킨 요청. Ref는 HTTP request_id 또는 Kafka topic/partition/offset.
+type Source struct {
+	Type string
+	Ref  string
+}
+
+type actorKey struct{}
+
+type sourceKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFrom(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorKey{}).(Actor)
+	return actor, ok
+}
+
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+func SourceFrom(ctx context.Context) (Source, bool) {
+	source, ok := ctx.Value(sourceKey{}).(Source)
+	return source, ok
+}
+
+func HTTPSource(requestID string) Source {
+	return Source{Type: SourceHTTP, Ref: requestID}
+}
+
+func KafkaSource(topic string, partition int, offset int64) Source {
+	return Source{Type: SourceKafka, Ref: fmt.Sprintf("%s/%d/%d", topic, partition, offset)}
+}
+
+// ForConsumer Kafka 메시지 하나를 처리하는 동안 사용할 context.
+func ForConsumer(ctx context.Context, consumer, topic string, partition int, offset int64) context.Context {
+	ctx = WithActor(ctx, Actor{Type: ActorConsumer, ID: consumer})
+	return WithSource(ctx, KafkaSource(topic, partition, offset))
+}
+
+// ForJob 백그라운드 job 실행 동안 사용할 context.
+func ForJob(ctx context.Context, job string) context.Context {
+	ctx = WithActor(ctx, Actor{Type: ActorJob, ID: job})
+	return WithSource(ctx, Source{Type: SourceJob, Ref: job})
+}
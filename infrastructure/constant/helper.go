@@ -6,6 +6,8 @@ const (
 	OrderStatusCompleted  = 2
 	OrderStatusCancelled  = 3
 	OrderStatusFailed     = 4
+	OrderStatusShipped    = 5
+	OrderStatusDelivered  = 6
 )
 
 var OrderStatusMap = map[int]string{
@@ -14,4 +16,34 @@ var OrderStatusMap = map[int]string{
 	OrderStatusCompleted:  "completed",
 	OrderStatusCancelled:  "cancelled",
 	OrderStatusFailed:     "failed",
+	OrderStatusShipped:    "shipped",
+	OrderStatusDelivered:  "delivered",
 }
+
+// OrderStatusTransitions 허용된 상태 전이 (from -> to 목록). 목록에 없는 전이는 모두 거부한다.
+var OrderStatusTransitions = map[int][]int{
+	OrderStatusCreated:    {OrderStatusProcessing, OrderStatusCompleted, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusProcessing: {OrderStatusCompleted, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusCompleted:  {OrderStatusShipped},
+	OrderStatusShipped:    {OrderStatusDelivered},
+	OrderStatusDelivered:  {},
+	OrderStatusCancelled:  {},
+	OrderStatusFailed:     {},
+}
+
+func CanTransitionOrderStatus(from, to int) bool {
+	for _, next := range OrderStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// 상태 이력(actor) 기록용
+const (
+	OrderActorUser   = "user"
+	OrderActorSystem = "system"
+	OrderActorAdmin  = "admin"
+	OrderActorSaga   = "saga"
+)
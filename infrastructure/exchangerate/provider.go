@@ -0,0 +1,90 @@
+// Package exchangerate 주문 통화 환산용 환율 공급자.
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"orderfc/config"
+	"orderfc/infrastructure/money"
+	"os"
+	"strings"
+)
+
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// Provider 환율 조회 인터페이스. 운영에서는 외부 환율 API 구현으로 교체할 수 있다.
+type Provider interface {
+	// Base 상품 가격과 리포트 기준이 되는 스토어 기준 통화.
+	Base() string
+	// Rate from 통화 1단위가 to 통화로 얼마인지 반환한다.
+	Rate(ctx context.Context, from, to string) (money.Rate, error)
+}
+
+// StaticProvider 설정값 또는 파일에서 읽은 고정 환율 (기준 통화 1단위 대비).
+type StaticProvider struct {
+	base  string
+	rates map[string]*big.Rat
+}
+
+// NewStaticProvider rates는 기준 통화 1단위가 각 통화로 얼마인지를 10진 문자열로 받는다.
+func NewStaticProvider(base string, rates map[string]string) (*StaticProvider, error) {
+	base = strings.ToUpper(base)
+	if base == "" {
+		base = money.DefaultCurrency
+	}
+	p := &StaticProvider{base: base, rates: map[string]*big.Rat{base: big.NewRat(1, 1)}}
+	for currency, value := range rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %q", currency, value)
+		}
+		p.rates[strings.ToUpper(currency)] = rate
+	}
+	return p, nil
+}
+
+type rateFile struct {
+	Base  string            `json:"base"`
+	Rates map[string]string `json:"rates"`
+}
+
+// NewFileProvider {"base": "KRW", "rates": {"USD": "0.00072"}} 형식의 JSON 파일을 읽는다.
+func NewFileProvider(path string) (*StaticProvider, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file rateFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, err
+	}
+	return NewStaticProvider(file.Base, file.Rates)
+}
+
+func (p *StaticProvider) Base() string {
+	return p.base
+}
+
+// Rate 기준 통화를 거쳐 교차 환율을 계산한다.
+func (p *StaticProvider) Rate(ctx context.Context, from, to string) (money.Rate, error) {
+	fromRate, ok := p.rates[strings.ToUpper(from)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrRateNotFound, from)
+	}
+	toRate, ok := p.rates[strings.ToUpper(to)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrRateNotFound, to)
+	}
+	return money.RateFromRat(new(big.Rat).Quo(toRate, fromRate)), nil
+}
+
+// NewProvider rates_file이 설정되어 있으면 파일을, 아니면 설정의 rates를 사용한다.
+func NewProvider(cfg config.CurrencyConfig) (Provider, error) {
+	if cfg.RatesFile != "" {
+		return NewFileProvider(cfg.RatesFile)
+	}
+	return NewStaticProvider(cfg.Base, cfg.Rates)
+}
@@ -0,0 +1,238 @@
+// Package money 주문 금액을 통화의 최소 단위(minor unit) 정수로 다룬다.
+//
+// 반올림 규칙:
+//   - 외부에서 들어온 소수 금액(productfc price 등)은 FromMajor/ParseDecimal에서 한 번만 minor unit으로 변환하며 half away from zero로 반올림한다.
+//   - 이후의 합계/수량 곱셈은 정수 연산이라 반올림이 없다.
+//   - 비율 계산(할인율, 세율, 환율 등)은 MulRatio를 사용하며 역시 half away from zero로 반올림한다.
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const DefaultCurrency = "KRW"
+
+var ErrInvalidAmount = errors.New("amount must be an integer number of minor units")
+
+// 통화별 소수 자릿수 (ISO 4217). 등록되지 않은 통화는 2자리로 취급한다.
+var currencyExponents = map[string]int{
+	"KRW": 0,
+	"JPY": 0,
+	"USD": 2,
+	"EUR": 2,
+	"CNY": 2,
+}
+
+// Amount 최소 단위 정수 금액. KRW는 1원, USD는 1센트.
+type Amount int64
+
+func Exponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+func IsSupported(currency string) bool {
+	_, ok := currencyExponents[strings.ToUpper(currency)]
+	return ok
+}
+
+// FromMajor 소수 금액(예: 12.34 USD)을 minor unit으로 변환한다.
+// float64의 이진 오차를 피하려고 최단 10진 표현으로 바꾼 뒤 변환한다.
+func FromMajor(value float64, currency string) Amount {
+	amount, _ := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64), currency)
+	return amount
+}
+
+// ParseDecimal 10진 문자열 금액을 minor unit으로 변환한다 (half away from zero).
+func ParseDecimal(value, currency string) (Amount, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return 0, fmt.Errorf("invalid decimal amount %q", value)
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Exponent(currency))), nil)
+	r.Mul(r, new(big.Rat).SetInt(scale))
+	return Amount(roundRat(r)), nil
+}
+
+func (a Amount) Mul(qty int) Amount {
+	return a * Amount(qty)
+}
+
+// MulRatio a * numerator / denominator 를 half away from zero로 반올림한다.
+func (a Amount) MulRatio(numerator, denominator int64) Amount {
+	r := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(int64(a)), big.NewInt(numerator)),
+		big.NewInt(denominator),
+	)
+	return Amount(roundRat(r))
+}
+
+// Format 통화 자릿수에 맞춘 10진 문자열 (예: 1234 USD -> "12.34").
+func (a Amount) Format(currency string) string {
+	exp := Exponent(currency)
+	if exp == 0 {
+		return strconv.FormatInt(int64(a), 10)
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(int64(a)), new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+	return r.FloatString(exp)
+}
+
+// ParseMinor 정수 minor unit 문자열을 변환한다. 15000.0 처럼 소수부가 0인 값은 받아들인다.
+func ParseMinor(value string) (Amount, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || !r.IsInt() || !r.Num().IsInt64() {
+		return 0, ErrInvalidAmount
+	}
+	return Amount(r.Num().Int64()), nil
+}
+
+// UnmarshalJSON 정수 minor unit만 허용한다. major unit 소수를 쓰던 이전 형식(저장된 legacy JSON,
+// schema_version 1 이벤트)은 json.Number로 받아 ParseDecimal로 변환한다.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return ErrInvalidAmount
+	}
+	amount, err := ParseMinor(number.String())
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+func roundRat(r *big.Rat) int64 {
+	num := new(big.Int).Set(r.Num())
+	den := r.Denom()
+	negative := num.Sign() < 0
+	num.Abs(num)
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if negative {
+		quo.Neg(quo)
+	}
+	return quo.Int64()
+}
+
+// RateScale Rate는 1e8 배율 고정소수점이다 (소수점 8자리).
+const RateScale = 100_000_000
+
+// Rate 환율. from 통화 1(major) 당 to 통화 금액(major)을 RateScale 배 한 정수.
+type Rate int64
+
+// ParseRate 10진 문자열 환율을 Rate로 변환한다 (8자리에서 half away from zero).
+func ParseRate(value string) (Rate, error) {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || r.Sign() <= 0 {
+		return 0, fmt.Errorf("invalid exchange rate %q", value)
+	}
+	return RateFromRat(r), nil
+}
+
+func RateFromRat(r *big.Rat) Rate {
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt64(RateScale))
+	return Rate(roundRat(scaled))
+}
+
+func (r Rate) Rat() *big.Rat {
+	return big.NewRat(int64(r), RateScale)
+}
+
+func (r Rate) String() string {
+	return r.Rat().FloatString(8)
+}
+
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+// UnmarshalJSON MarshalJSON의 10진 문자열을 받는다. 숫자 리터럴도 허용한다.
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		var number json.Number
+		if err := json.Unmarshal(data, &number); err != nil {
+			return fmt.Errorf("invalid exchange rate %s", data)
+		}
+		value = number.String()
+	}
+	rate, err := ParseRate(value)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// Convert from 통화 금액을 환율로 to 통화 금액으로 바꾼다. 자릿수 차이를 반영하고 half away from zero로 반올림한다.
+func Convert(amount Amount, from, to string, rate Rate) Amount {
+	if strings.EqualFold(from, to) {
+		return amount
+	}
+	numerator := new(big.Int).Mul(big.NewInt(int64(amount)), big.NewInt(int64(rate)))
+	numerator.Mul(numerator, pow10(Exponent(to)))
+	denominator := new(big.Int).Mul(big.NewInt(RateScale), pow10(Exponent(from)))
+	return Amount(roundRat(new(big.Rat).SetFrac(numerator, denominator)))
+}
+
+func pow10(exp int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil)
+}
+
+// Inverse to -> from 방향 환율.
+func (r Rate) Inverse() Rate {
+	return RateFromRat(new(big.Rat).Inv(r.Rat()))
+}
+
+// Allocate total을 weights 비율로 나눈다 (largest remainder). 결과의 합은 항상 total과 같다.
+func Allocate(total Amount, weights []Amount) []Amount {
+	shares := make([]Amount, len(weights))
+	var weightSum int64
+	for _, w := range weights {
+		weightSum += int64(w)
+	}
+	if weightSum <= 0 || total == 0 {
+		return shares
+	}
+
+	type remainder struct {
+		index int
+		value *big.Int
+	}
+	remainders := make([]remainder, len(weights))
+	var allocated Amount
+	for i, w := range weights {
+		quo, rem := new(big.Int).QuoRem(
+			new(big.Int).Mul(big.NewInt(int64(total)), big.NewInt(int64(w))),
+			big.NewInt(weightSum),
+			new(big.Int),
+		)
+		shares[i] = Amount(quo.Int64())
+		allocated += shares[i]
+		remainders[i] = remainder{index: i, value: rem.Abs(rem)}
+	}
+
+	sort.SliceStable(remainders, func(a, b int) bool {
+		return remainders[a].value.Cmp(remainders[b].value) > 0
+	})
+	step := Amount(1)
+	if total < 0 {
+		step = -1
+	}
+	for i := 0; allocated != total && i < len(remainders); i++ {
+		shares[remainders[i].index] += step
+		allocated += step
+	}
+	return shares
+}
@@ -0,0 +1,178 @@
+package money
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMulRatioRoundsHalfAwayFromZero(t *testing.T) {
+	tests := []struct {
+		amount                 Amount
+		numerator, denominator int64
+		want                   Amount
+	}{
+		{amount: 4, numerator: 1, denominator: 2, want: 2},
+		{amount: 5, numerator: 1, denominator: 2, want: 3},
+		{amount: -5, numerator: 1, denominator: 2, want: -3},
+		{amount: -7, numerator: 1, denominator: 2, want: -4},
+		{amount: 10, numerator: 1, denominator: 3, want: 3},
+		{amount: 20, numerator: 1, denominator: 3, want: 7},
+		{amount: 10000, numerator: 1000, denominator: 11000, want: 909},
+		{amount: 35000, numerator: 333, denominator: 10000, want: 1166},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d*%d/%d", tt.amount, tt.numerator, tt.denominator), func(t *testing.T) {
+			if got := tt.amount.MulRatio(tt.numerator, tt.denominator); got != tt.want {
+				t.Errorf("MulRatio = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     Amount
+		wantErr  bool
+	}{
+		{value: "12.34", currency: "USD", want: 1234},
+		{value: "12.345", currency: "USD", want: 1235},
+		{value: "-0.125", currency: "USD", want: -13},
+		{value: "999.5", currency: "KRW", want: 1000},
+		{value: "10", currency: "JPY", want: 10},
+		{value: "1.5", currency: "XYZ", want: 150}, // 등록되지 않은 통화는 2자리
+		{value: "abc", currency: "USD", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value+" "+tt.currency, func(t *testing.T) {
+			got, err := ParseDecimal(tt.value, tt.currency)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDecimal = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// float64 이진 표현(1.005 = 1.00499999...)에 끌려 내림되지 않아야 한다.
+func TestFromMajorUsesShortestDecimal(t *testing.T) {
+	tests := []struct {
+		value    float64
+		currency string
+		want     Amount
+	}{
+		{value: 1.005, currency: "USD", want: 101},
+		{value: 0.1 + 0.2, currency: "USD", want: 30},
+		{value: 15000, currency: "KRW", want: 15000},
+		{value: 1234.5, currency: "KRW", want: 1235},
+	}
+	for _, tt := range tests {
+		if got := FromMajor(tt.value, tt.currency); got != tt.want {
+			t.Errorf("FromMajor(%v, %s) = %d, want %d", tt.value, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestParseMinor(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Amount
+		wantErr bool
+	}{
+		{value: "15000", want: 15000},
+		{value: "15000.0", want: 15000},
+		{value: "-300", want: -300},
+		{value: "12.5", wantErr: true},
+		{value: "1e30", wantErr: true},
+		{value: "abc", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMinor(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseMinor(%q) = %d, %v; want %d, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Rate
+		wantErr bool
+	}{
+		{value: "1300", want: 1300 * RateScale},
+		{value: "0.00075", want: 75000},
+		{value: "0.000000015", want: 2},
+		{value: "0", wantErr: true},
+		{value: "-1", wantErr: true},
+		{value: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseRate(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseRate(%q) = %d, %v; want %d, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRateInverse(t *testing.T) {
+	tests := []struct {
+		rate Rate
+		want Rate
+	}{
+		{rate: RateScale, want: RateScale},
+		{rate: 1300 * RateScale, want: 76923},
+		{rate: 75000, want: 133333333333},
+	}
+	for _, tt := range tests {
+		if got := tt.rate.Inverse(); got != tt.want {
+			t.Errorf("%s.Inverse() = %d, want %d", tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Amount
+		from, to string
+		rate     Rate
+		want     Amount
+	}{
+		{name: "same currency", amount: 1234, from: "USD", to: "usd", rate: 2 * RateScale, want: 1234},
+		{name: "KRW to USD", amount: 10000, from: "KRW", to: "USD", rate: 75000, want: 750},
+		{name: "USD to KRW", amount: 150, from: "USD", to: "KRW", rate: 133333333333, want: 2000},
+		{name: "half rounds up", amount: 5, from: "KRW", to: "USD", rate: 100000, want: 1},
+		{name: "negative half rounds down", amount: -5, from: "KRW", to: "USD", rate: 100000, want: -1},
+		{name: "JPY to KRW", amount: 3, from: "JPY", to: "KRW", rate: 950000000, want: 29},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Convert(tt.amount, tt.from, tt.to, tt.rate); got != tt.want {
+				t.Errorf("Convert = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		total   Amount
+		weights []Amount
+		want    []Amount
+	}{
+		{total: 10, weights: []Amount{1, 1, 1}, want: []Amount{4, 3, 3}},
+		{total: -10, weights: []Amount{1, 1, 1}, want: []Amount{-4, -3, -3}},
+		{total: 1000, weights: []Amount{10000, 5000}, want: []Amount{667, 333}},
+		{total: 100, weights: []Amount{0, 0}, want: []Amount{0, 0}},
+	}
+	for _, tt := range tests {
+		got := Allocate(tt.total, tt.weights)
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("Allocate(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+		}
+	}
+}
@@ -4,11 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"orderfc/cmd/order/service"
-	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/audit"
 	"orderfc/infrastructure/log"
 	kafkaFC "orderfc/kafka"
 	"orderfc/models"
-	"time"
+	"orderfc/saga"
 
 	"github.com/segmentio/kafka-go"
 )
@@ -39,48 +39,20 @@ func (e *PaymentFailedConsumer) StartPaymentFailedConsumer(ctx context.Context)
 			log.Logger.Error().Err(err).Msg("Failed to read message from Kafka")
 			continue
 		}
+		msgCtx := audit.ForConsumer(ctx, "payment-failed-consumer", msg.Topic, msg.Partition, msg.Offset)
 
 		var event models.PaymentUpdateStatusEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
 			log.Logger.Error().Err(err).Msg("Failed to unmarshal message from Kafka")
 			continue
 		}
-		err = e.OrderService.UpdateOrderStatus(ctx, event.OrderID, constant.OrderStatusCancelled)
+		// 주문 취소와 stock.rollback 발행은 saga 보상 step에서 처리한다.
+		err = e.OrderService.AdvanceSaga(msgCtx, event.OrderID, saga.Event{Type: saga.EventPaymentFailed, Reason: "payment_failed"})
 		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to update order status")
-			continue
-		}
-
-		orderInfo, err := e.OrderService.GetOrderInfoByOrderID(ctx, event.OrderID)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to get order info")
-			continue
-		}
-
-		orderDetail, err := e.OrderService.GetOrderDetailByID(ctx, orderInfo.OrderDetailID)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to get order detail")
-			continue
-		}
-
-		var products []models.CheckoutItem
-		err = json.Unmarshal([]byte(orderDetail.Products), &products)
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to unmarshal products")
-			continue
-		}
-
-		productItems := convertCheckoutItemToProductItem(products)
-
-		err = e.KafkaProducer.PublishStockRollback(ctx, models.ProductStockUpdatedEvent{
-			SchemaVersion: 1,
-			OrderID:       event.OrderID,
-			UserID:        orderInfo.UserID,
-			Products:      productItems,
-			EventTime:     time.Now(),
-		})
-		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to publish stock rollback event")
+			if dropStaleSagaEvent(msg, event.OrderID, err) {
+				continue
+			}
+			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("Failed to advance order saga")
 			continue
 		}
 	}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"orderfc/cmd/order/service"
-	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/audit"
 	"orderfc/infrastructure/log"
 	kafkaFC "orderfc/kafka"
 	"orderfc/models"
@@ -39,24 +39,28 @@ func (e *PaymentConsumer) StartPaymentSuccessConsumer(ctx context.Context) {
 			log.Logger.Error().Err(err).Msg("Failed to read message from Kafka")
 			continue
 		}
+		msgCtx := audit.ForConsumer(ctx, "payment-success-consumer", msg.Topic, msg.Partition, msg.Offset)
 		var event models.PaymentUpdateStatusEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
 			log.Logger.Error().Err(err).Msg("Failed to unmarshal message from Kafka")
 			continue
 		}
-		err = e.OrderService.UpdateOrderStatus(ctx, event.OrderID, constant.OrderStatusCompleted)
+		err = e.OrderService.HandlePaymentSucceeded(msgCtx, event.OrderID)
 		if err != nil {
-			log.Logger.Error().Err(err).Msg("Failed to update order status")
+			if dropStaleSagaEvent(msg, event.OrderID, err) {
+				continue
+			}
+			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("Failed to advance order saga")
 			continue
 		}
 
-		// orderInfo, err := e.OrderService.GetOrderInfoByOrderID(ctx, event.OrderID)
+		// orderInfo, err := e.OrderService.GetOrderInfoByOrderID(msgCtx, event.OrderID)
 		// if err != nil {
 		// 	log.Logger.Error().Err(err).Msg("Failed to get order info")
 		// 	continue
 		// }
 
-		// orderDetail, err := e.OrderService.GetOrderDetailByOrderID(ctx, orderInfo.OrderDetailID)
+		// orderDetail, err := e.OrderService.GetOrderDetailByOrderID(msgCtx, orderInfo.OrderDetailID)
 		// if err != nil {
 		// 	log.Logger.Error().Err(err).Msg("Failed to get order detail")
 		// 	continue
@@ -0,0 +1,48 @@
+package consumer
+
+import (
+	"errors"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/log"
+	"orderfc/saga"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/segmentio/kafka-go"
+)
+
+var droppedSagaEvents = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "commerce",
+		Subsystem: "order_saga",
+		Name:      "dropped_events_total",
+		Help:      "Saga response events skipped by topic and reason (finished, unexpected, refunded)",
+	},
+	[]string{"topic", "reason"},
+)
+
+// dropStaleSagaEvent 중복 수신이나 saga 종료 후 늦게 도착한 이벤트는 재처리해도 결과가 같아 건너뛴다.
+// saga 상태와 맞지 않는 이벤트는 순서 역전이나 상태 머신 버그일 수 있어 메시지 위치와 함께 에러로 남긴다.
+// 취소된 주문의 결제 완료는 환불 요청을 발행한 뒤 별도 reason으로 남긴다 (고객에게 청구된 상태라 추적이 필요하다).
+func dropStaleSagaEvent(msg kafka.Message, orderID int64, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrLatePaymentRefunded):
+		droppedSagaEvents.WithLabelValues(msg.Topic, "refunded").Inc()
+		log.Logger.Error().Err(err).Int64("order_id", orderID).Str("topic", msg.Topic).
+			Int("partition", msg.Partition).Int64("offset", msg.Offset).
+			Msg("Payment succeeded for a cancelled order - refund requested")
+		return true
+	case errors.Is(err, saga.ErrSagaFinished):
+		droppedSagaEvents.WithLabelValues(msg.Topic, "finished").Inc()
+		log.Logger.Warn().Err(err).Int64("order_id", orderID).Str("topic", msg.Topic).
+			Msg("Ignoring saga event for finished saga")
+		return true
+	case errors.Is(err, saga.ErrUnexpectedEvent):
+		droppedSagaEvents.WithLabelValues(msg.Topic, "unexpected").Inc()
+		log.Logger.Error().Err(err).Int64("order_id", orderID).Str("topic", msg.Topic).
+			Int("partition", msg.Partition).Int64("offset", msg.Offset).Str("key", string(msg.Key)).
+			Msg("Dropping saga event that does not match saga state")
+		return true
+	}
+	return false
+}
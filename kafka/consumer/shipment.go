@@ -0,0 +1,82 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/audit"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// ShipmentConsumer 물류 연동 서비스의 shipment.shipped / shipment.delivered 이벤트로 주문 배송 상태를 갱신한다.
+type ShipmentConsumer struct {
+	Reader       *kafka.Reader
+	Topic        string
+	OrderService *service.OrderService
+}
+
+func NewShipmentConsumer(brokers []string, topic string, orderService *service.OrderService) *ShipmentConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: "orderfc",
+	})
+	return &ShipmentConsumer{
+		Reader:       reader,
+		Topic:        topic,
+		OrderService: orderService,
+	}
+}
+
+func (c *ShipmentConsumer) Start(ctx context.Context) {
+	actor := "consumer:" + c.Topic
+	for {
+		msg, err := c.Reader.ReadMessage(ctx)
+		if err != nil {
+			log.Logger.Error().Err(err).Str("topic", c.Topic).Msg("Failed to read shipment message")
+			continue
+		}
+		msgCtx := audit.ForConsumer(ctx, "shipment-consumer", msg.Topic, msg.Partition, msg.Offset)
+
+		var event models.ShipmentEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			log.Logger.Error().Err(err).Str("topic", c.Topic).Msg("Failed to unmarshal shipment message")
+			continue
+		}
+		eventTime := event.EventTime
+		if eventTime.IsZero() {
+			eventTime = time.Now()
+		}
+
+		switch c.Topic {
+		case "shipment.shipped":
+			err = c.OrderService.ShipOrder(msgCtx, &models.Shipment{
+				OrderID:        event.OrderID,
+				Carrier:        event.Carrier,
+				TrackingNumber: event.TrackingNumber,
+				ShippedAt:      &eventTime,
+			}, actor)
+		case "shipment.delivered":
+			err = c.OrderService.MarkOrderDelivered(msgCtx, event.OrderID, eventTime, actor)
+		default:
+			log.Logger.Warn().Str("topic", c.Topic).Msg("Unknown shipment topic")
+			continue
+		}
+		if err != nil {
+			// 관리자 출고 처리 후 같은 이벤트가 다시 오는 경우 등 중복 전이는 무시한다.
+			if errors.Is(err, service.ErrInvalidStatusTransition) {
+				log.Logger.Warn().Err(err).Int64("order_id", event.OrderID).Str("topic", c.Topic).Msg("Ignoring shipment event for order in unexpected status")
+				continue
+			}
+			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Str("topic", c.Topic).Msg("Failed to apply shipment event")
+			continue
+		}
+
+		log.Logger.Info().Int64("order_id", event.OrderID).Str("topic", c.Topic).Msg("Shipment event applied")
+	}
+}
@@ -4,9 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"orderfc/cmd/order/service"
-	"orderfc/infrastructure/constant"
+	"orderfc/infrastructure/audit"
 	"orderfc/infrastructure/log"
 	"orderfc/models"
+	"orderfc/saga"
 
 	"github.com/segmentio/kafka-go"
 )
@@ -35,15 +36,25 @@ func (c *StockRejectedConsumer) Start(ctx context.Context) {
 			log.Logger.Error().Err(err).Msg("Failed to read stock.rejected message")
 			continue
 		}
+		msgCtx := audit.ForConsumer(ctx, "stock-rejected-consumer", msg.Topic, msg.Partition, msg.Offset)
 
 		var event models.StockReservationEvent
 		if err := json.Unmarshal(msg.Value, &event); err != nil {
 			log.Logger.Error().Err(err).Msg("Failed to unmarshal stock.rejected message")
 			continue
 		}
+		// 예약이 거절됐다면 캐시된 재고가 실제와 달랐을 수 있으므로 버린다.
+		c.OrderService.InvalidateProductCache(msgCtx, event.ProductIDs()...)
 
-		if err := c.OrderService.UpdateOrderStatus(ctx, event.OrderID, constant.OrderStatusCancelled); err != nil {
-			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("Failed to cancel order after stock rejection")
+		reason := event.Reason
+		if reason == "" {
+			reason = "stock_rejected"
+		}
+		if err := c.OrderService.AdvanceSaga(msgCtx, event.OrderID, saga.Event{Type: saga.EventStockRejected, Reason: reason}); err != nil {
+			if dropStaleSagaEvent(msg, event.OrderID, err) {
+				continue
+			}
+			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("Failed to advance order saga after stock rejection")
 			continue
 		}
 
@@ -0,0 +1,59 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/audit"
+	"orderfc/infrastructure/log"
+	"orderfc/models"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// StockReservedConsumer productfc 재고 예약 완료 이벤트로 주문을 processing으로 옮기고 결제를 요청한다.
+type StockReservedConsumer struct {
+	Reader       *kafka.Reader
+	OrderService *service.OrderService
+}
+
+func NewStockReservedConsumer(brokers []string, topic string, orderService *service.OrderService) *StockReservedConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: brokers,
+		Topic:   topic,
+		GroupID: "orderfc",
+	})
+	return &StockReservedConsumer{
+		Reader:       reader,
+		OrderService: orderService,
+	}
+}
+
+func (c *StockReservedConsumer) Start(ctx context.Context) {
+	for {
+		msg, err := c.Reader.ReadMessage(ctx)
+		if err != nil {
+			log.Logger.Error().Err(err).Msg("Failed to read stock.reserved message")
+			continue
+		}
+		msgCtx := audit.ForConsumer(ctx, "stock-reserved-consumer", msg.Topic, msg.Partition, msg.Offset)
+
+		var event models.StockReservationEvent
+		if err := json.Unmarshal(msg.Value, &event); err != nil {
+			log.Logger.Error().Err(err).Msg("Failed to unmarshal stock.reserved message")
+			continue
+		}
+		// productfc 재고가 바뀌었으므로 캐시된 상품 정보를 버린다.
+		c.OrderService.InvalidateProductCache(msgCtx, event.ProductIDs()...)
+
+		if err := c.OrderService.HandleStockReserved(msgCtx, event); err != nil {
+			if dropStaleSagaEvent(msg, event.OrderID, err) {
+				continue
+			}
+			log.Logger.Error().Err(err).Int64("order_id", event.OrderID).Msg("Failed to handle stock reservation")
+			continue
+		}
+
+		log.Logger.Info().Int64("order_id", event.OrderID).Msg("Order moved to processing after stock reservation")
+	}
+}
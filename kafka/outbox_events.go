@@ -0,0 +1,105 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"orderfc/infrastructure/constant"
+	"orderfc/models"
+	"time"
+)
+
+// NewStockRollbackOutboxEvent stock.rollback 이벤트를 outbox 레코드로 만든다. 파티션 키는 직접 발행과 동일하다.
+func NewStockRollbackOutboxEvent(orderID, userID int64, products []models.ProductItem) (models.OrderOutboxEvent, error) {
+	return newStockOutboxEvent("stock.rollback", orderID, userID, products)
+}
+
+// NewStockUpdatedOutboxEvent stock.updated 이벤트 (추가 재고 차감). 주문 변경 시 늘어난 수량만 담는다.
+func NewStockUpdatedOutboxEvent(orderID, userID int64, products []models.ProductItem) (models.OrderOutboxEvent, error) {
+	return newStockOutboxEvent("stock.updated", orderID, userID, products)
+}
+
+func newStockOutboxEvent(topic string, orderID, userID int64, products []models.ProductItem) (models.OrderOutboxEvent, error) {
+	payload, err := json.Marshal(models.ProductStockUpdatedEvent{
+		SchemaVersion: 1,
+		OrderID:       orderID,
+		UserID:        userID,
+		Products:      products,
+		EventTime:     time.Now(),
+	})
+	if err != nil {
+		return models.OrderOutboxEvent{}, err
+	}
+	return models.OrderOutboxEvent{
+		Topic:    topic,
+		EventKey: string(StockEventPartitionKey(userID, orderID)),
+		Payload:  string(payload),
+		Status:   models.OrderOutboxStatusPending,
+	}, nil
+}
+
+// NewOrderStatusChangedOutboxEvent order.status_changed 이벤트. 같은 주문의 전이 순서를 지키기 위해 order ID를 키로 쓴다.
+func NewOrderStatusChangedOutboxEvent(order *models.Order, from, to int, actor, reason string) (models.OrderOutboxEvent, error) {
+	payload, err := json.Marshal(models.OrderStatusChangedEvent{
+		SchemaVersion: 1,
+		OrderID:       order.ID,
+		UserID:        order.UserID,
+		FromStatus:    constant.OrderStatusMap[from],
+		ToStatus:      constant.OrderStatusMap[to],
+		Actor:         actor,
+		Reason:        reason,
+		EventTime:     time.Now(),
+	})
+	if err != nil {
+		return models.OrderOutboxEvent{}, err
+	}
+	return models.OrderOutboxEvent{
+		Topic:    "order.status_changed",
+		EventKey: fmt.Sprintf("order-%d", order.ID),
+		Payload:  string(payload),
+		Status:   models.OrderOutboxStatusPending,
+	}, nil
+}
+
+// NewPaymentRequestedOutboxEvent payment.requested 이벤트. order.created와 같은 order ID 키를 쓴다.
+func NewPaymentRequestedOutboxEvent(order *models.Order) (models.OrderOutboxEvent, error) {
+	payload, err := json.Marshal(models.PaymentRequestedEvent{
+		SchemaVersion: 1,
+		OrderID:       order.ID,
+		UserID:        order.UserID,
+		Amount:        order.Amount,
+		Currency:      order.Currency,
+		PaymentMethod: order.PaymentMethod,
+		EventTime:     time.Now(),
+	})
+	if err != nil {
+		return models.OrderOutboxEvent{}, err
+	}
+	return models.OrderOutboxEvent{
+		Topic:    "payment.requested",
+		EventKey: fmt.Sprintf("order-%d", order.ID),
+		Payload:  string(payload),
+		Status:   models.OrderOutboxStatusPending,
+	}, nil
+}
+
+// NewPaymentRefundRequestedOutboxEvent payment.refund_requested 이벤트. payment.requested와 같은 키로 결제 이벤트 순서를 지킨다.
+func NewPaymentRefundRequestedOutboxEvent(order *models.Order, reason string) (models.OrderOutboxEvent, error) {
+	payload, err := json.Marshal(models.PaymentRefundRequestedEvent{
+		SchemaVersion: 1,
+		OrderID:       order.ID,
+		UserID:        order.UserID,
+		Amount:        order.Amount,
+		Currency:      order.Currency,
+		Reason:        reason,
+		EventTime:     time.Now(),
+	})
+	if err != nil {
+		return models.OrderOutboxEvent{}, err
+	}
+	return models.OrderOutboxEvent{
+		Topic:    "payment.refund_requested",
+		EventKey: fmt.Sprintf("order-%d", order.ID),
+		Payload:  string(payload),
+		Status:   models.OrderOutboxStatusPending,
+	}, nil
+}
@@ -21,7 +21,7 @@ func NewKafkaProducer(brokers []string) *KafkaProducer {
 	return &KafkaProducer{writer: writer}
 }
 
-func stockEventPartitionKey(userID, orderID int64) []byte {
+func StockEventPartitionKey(userID, orderID int64) []byte {
 	if userID > 0 {
 		return []byte(fmt.Sprintf("user-%d", userID))
 	}
@@ -51,7 +51,7 @@ func (p *KafkaProducer) PublishProductStockUpdated(ctx context.Context, event mo
 		return err
 	}
 	msg := kafka.Message{
-		Key:   stockEventPartitionKey(event.UserID, event.OrderID),
+		Key:   StockEventPartitionKey(event.UserID, event.OrderID),
 		Value: json,
 		Topic: "stock.updated",
 	}
@@ -73,7 +73,7 @@ func (p *KafkaProducer) PublishStockRollback(ctx context.Context, event models.P
 		return err
 	}
 	msg := kafka.Message{
-		Key:   stockEventPartitionKey(event.UserID, event.OrderID),
+		Key:   StockEventPartitionKey(event.UserID, event.OrderID),
 		Value: json,
 		Topic: "stock.rollback",
 	}
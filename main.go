@@ -8,12 +8,17 @@ import (
 	"orderfc/cmd/order/service"
 	"orderfc/cmd/order/usecase"
 	"orderfc/config"
+	"orderfc/infrastructure/exchangerate"
 	"orderfc/infrastructure/log"
 	"orderfc/kafka/consumer"
 	"orderfc/middleware"
 	"orderfc/models"
+	"orderfc/productclient"
 	"orderfc/routes"
+	"orderfc/shipping"
+	"orderfc/tax"
 	"orderfc/tracing"
+	"orderfc/worker"
 
 	"orderfc/kafka"
 
@@ -22,6 +27,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// orderSearchBackfillBatchSize 기동 시 검색 문서 backfill을 한 문장에서 처리하는 주문 수.
+const orderSearchBackfillBatchSize = 500
+
 // @title           ORDERFC API
 // @version         1.0
 // @description     Order creation and history for Go Commerce.
@@ -44,25 +52,80 @@ func main() {
 	redis := resource.InitRedis(cfg.Redis)
 	db := resource.InitDB(cfg.Database)
 
-	// AutoMigrate: order_detail, orders, order_request_log, order_outbox_events 테이블 자동 생성/업데이트
-	if err := db.AutoMigrate(&models.OrderDetail{}, &models.Order{}, &models.OrderRequestLog{}, &models.OrderOutboxEvent{}); err != nil {
+	exchangeRates, err := exchangerate.NewProvider(cfg.Currency)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to load exchange rates")
+	}
+
+	// 기존 numeric 금액 컬럼은 AutoMigrate가 타입을 바꾸기 전에 minor unit으로 변환한다.
+	if err := repository.ConvertLegacyOrderAmounts(context.Background(), db, exchangeRates.Base()); err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to convert legacy order amounts")
+	}
+
+	// AutoMigrate: order_detail, orders, order_items, order_request_log, order_outbox_events, 프로모션, 세금 라인, 배송, saga, 감사 로그 테이블 자동 생성/업데이트
+	if err := db.AutoMigrate(
+		&models.OrderDetail{}, &models.Order{}, &models.OrderItem{}, &models.OrderRequestLog{}, &models.OrderOutboxEvent{},
+		&models.Promotion{}, &models.CouponRedemption{}, &models.OrderDiscount{}, &models.OrderTaxLine{}, &models.Shipment{},
+		&models.OrderSaga{}, &models.OrderSagaStep{}, &models.OrderAuditLog{},
+	); err != nil {
 		log.Logger.Fatal().Err(err).Msg("Failed to migrate database")
 	}
-	log.Logger.Info().Msg("Database migration completed - order_detail, orders, order_request_log, and order_outbox_events tables created")
+	log.Logger.Info().Msg("Database migration completed - order_detail, orders, order_items, order_request_log, order_outbox_events, promotion, tax line, shipment, saga, and audit log tables created")
+
+	taxCalculator, err := tax.NewRuleBasedCalculator(cfg.Tax)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to load tax rules")
+	}
+
+	shippingCalculator := shipping.NewRuleBasedCalculator(cfg.Shipping, cfg.Tax.DefaultRegion)
+
+	productCatalog, err := productclient.NewCatalog(cfg.Product)
+	if err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to create product catalog client")
+	}
+
+	// 견적 토큰은 JWT와 다른 키로 서명한다. 한쪽 키가 유출돼도 다른 쪽 토큰을 위조할 수 없게 한다.
+	if cfg.Quote.Secret == "" {
+		log.Logger.Fatal().Msg("quote.secret is not set")
+	}
+	if cfg.Quote.Secret == config.GetJwtSecret() {
+		log.Logger.Fatal().Msg("quote.secret must differ from secret.jwt_secret")
+	}
 
 	kafkaProducer := kafka.NewKafkaProducer(cfg.Kafka.Brokers)
 
 	defer kafkaProducer.Close()
 	// 의존성 주입
-	orderRepository := repository.NewOrderRepository(db, redis, cfg.Product.Host)
-	orderService := service.NewOrderService(*orderRepository)
-	orderUsecase := usecase.NewOrderUsecase(*orderService, kafkaProducer)
+	orderRepository := repository.NewOrderRepository(db, redis, productCatalog, cfg.Product)
+	orderService := service.NewOrderService(orderRepository)
+	if err := orderService.MigrateLegacyOrderCurrency(context.Background(), exchangeRates.Base()); err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to migrate legacy order currency columns")
+	}
+	if err := orderService.EnsureOrderAuditLogAppendOnly(context.Background()); err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to install order audit log trigger")
+	}
+	if backfilled, err := orderService.BackfillOrderSearch(context.Background(), orderSearchBackfillBatchSize); err != nil {
+		log.Logger.Fatal().Err(err).Msg("Failed to backfill order search documents")
+	} else if backfilled > 0 {
+		log.Logger.Info().Int64("orders", backfilled).Msg("Backfilled order search documents")
+	}
+	orderUsecase := usecase.NewOrderUsecase(*orderService, kafkaProducer, exchangeRates, taxCalculator, shippingCalculator, cfg.Cart, cfg.Quote)
 	orderHandler := handler.NewOrderHandler(*orderUsecase)
 
 	orderOutboxPublisher := kafka.NewOrderOutboxPublisher(orderRepository, kafkaProducer)
 	go orderOutboxPublisher.Start(context.Background())
 	log.Logger.Info().Msg("Order outbox publisher started")
 
+	sagaRecoveryWorker := worker.NewSagaRecoveryWorker(orderService)
+	go sagaRecoveryWorker.Start(context.Background())
+	log.Logger.Info().Msg("Saga recovery worker started")
+
+	if cfg.Order.PaymentTimeout > 0 {
+		orderExpirySweeper := worker.NewOrderExpirySweeper(orderService, cfg.Order)
+		go orderExpirySweeper.Start(context.Background())
+		log.Logger.Info().Dur("payment_timeout", cfg.Order.PaymentTimeout).Msg("Order expiry sweeper started")
+	}
+
 	port := cfg.App.Port
 	router := gin.Default()
 	router.Use(middleware.PrometheusRED("orderfc"))
@@ -83,10 +146,20 @@ func main() {
 	go kafkaPaymentFailedConsumer.StartPaymentFailedConsumer(context.Background())
 	log.Logger.Info().Msg("Kafka payment failed consumer started")
 
+	kafkaStockReservedConsumer := consumer.NewStockReservedConsumer(cfg.Kafka.Brokers, "stock.reserved", orderService)
+	go kafkaStockReservedConsumer.Start(context.Background())
+	log.Logger.Info().Msg("Kafka stock reserved consumer started")
+
 	kafkaStockRejectedConsumer := consumer.NewStockRejectedConsumer(cfg.Kafka.Brokers, "stock.rejected", orderService)
 	go kafkaStockRejectedConsumer.Start(context.Background())
 	log.Logger.Info().Msg("Kafka stock rejected consumer started")
 
+	for _, topic := range []string{"shipment.shipped", "shipment.delivered"} {
+		kafkaShipmentConsumer := consumer.NewShipmentConsumer(cfg.Kafka.Brokers, topic, orderService)
+		go kafkaShipmentConsumer.Start(context.Background())
+	}
+	log.Logger.Info().Msg("Kafka shipment consumers started")
+
 	log.Logger.Info().Msgf("Server is running on port %s", port)
 	router.Run(":" + port)
 }
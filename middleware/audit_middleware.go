@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"orderfc/infrastructure/audit"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuditActor AuthMiddleware 뒤에 사용한다. 인증된 사용자와 request_id를 감사 로그용 context에 싣는다.
+func AuditActor(actorType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		if userID, ok := c.Get("user_id"); ok {
+			if id, ok := userID.(float64); ok {
+				ctx = audit.WithActor(ctx, audit.Actor{Type: actorType, ID: strconv.FormatInt(int64(id), 10)})
+			}
+		}
+		if requestID, ok := ctx.Value("request_id").(string); ok {
+			ctx = audit.WithSource(ctx, audit.HTTPSource(requestID))
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -40,6 +40,8 @@ func AuthMiddleware(secret string) gin.HandlerFunc {
 			return
 		}
 		c.Set("user_id", claims["user_id"].(float64))
+		c.Set("roles", claimRoles(claims))
+		c.Set("scopes", claimScopes(claims))
 		c.Next()
 	}
 }
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+)
+
+// RequireRole AuthMiddleware 뒤에 사용한다. JWT role/roles 클레임에 roles 중 하나가 없으면 403.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, allowed := range roles {
+			if HasRole(c, allowed) {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "insufficient role"})
+		c.Abort()
+	}
+}
+
+// RequireScope JWT scope/scopes 클레임에 scopes가 모두 있어야 통과한다.
+func RequireScope(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted := Scopes(c)
+		for _, required := range scopes {
+			if !contains(granted, required) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "insufficient scope"})
+				c.Abort()
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// Roles AuthMiddleware가 토큰에서 꺼낸 역할 목록.
+func Roles(c *gin.Context) []string {
+	return c.GetStringSlice("roles")
+}
+
+// Scopes AuthMiddleware가 토큰에서 꺼낸 스코프 목록.
+func Scopes(c *gin.Context) []string {
+	return c.GetStringSlice("scopes")
+}
+
+func HasRole(c *gin.Context, role string) bool {
+	return contains(Roles(c), role)
+}
+
+// claimRoles 단일 role 클레임과 roles 배열 클레임을 합친다.
+func claimRoles(claims jwt.MapClaims) []string {
+	var roles []string
+	if role, ok := claims["role"].(string); ok && role != "" {
+		roles = append(roles, role)
+	}
+	for _, role := range claimStrings(claims["roles"]) {
+		if !contains(roles, role) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// claimScopes OAuth2 형식의 공백 구분 scope 문자열과 scopes 배열을 모두 받는다.
+func claimScopes(claims jwt.MapClaims) []string {
+	var scopes []string
+	if scope, ok := claims["scope"].(string); ok {
+		scopes = append(scopes, strings.Fields(scope)...)
+	}
+	for _, scope := range claimStrings(claims["scopes"]) {
+		if !contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+func claimStrings(value interface{}) []string {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok && s != "" {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func contains(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
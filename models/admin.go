@@ -0,0 +1,8 @@
+package models
+
+// AdminOrderStatusRequest 관리자 주문 상태 변경 요청. force면 상태 머신이 허용하지 않는 전이도 적용한다.
+type AdminOrderStatusRequest struct {
+	Status *int   `json:"status"`
+	Reason string `json:"reason"`
+	Force  bool   `json:"force"`
+}
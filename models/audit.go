@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// 감사 로그 action
+const (
+	OrderAuditActionCreated          = "created"
+	OrderAuditActionAmended          = "amended"
+	OrderAuditActionStatusChanged    = "status_changed"
+	OrderAuditActionStatusOverridden = "status_overridden"
+)
+
+// OrderAuditLog 주문 변경 감사 로그. 추가만 가능하며 DB 트리거가 UPDATE/DELETE를 막는다.
+// ActorType/SourceType 값은 infrastructure/audit 패키지 상수를 따른다.
+type OrderAuditLog struct {
+	ID         int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID    int64     `gorm:"type:bigint;not null;index:idx_order_audit_log_order" json:"order_id"`
+	Action     string    `gorm:"type:varchar(30);not null" json:"action"`
+	ActorType  string    `gorm:"type:varchar(20);not null" json:"actor_type"`
+	ActorID    string    `gorm:"type:varchar(100);not null;default:''" json:"actor_id"`
+	FromStatus *int      `gorm:"type:integer" json:"from_status"`
+	ToStatus   *int      `gorm:"type:integer" json:"to_status"`
+	SourceType string    `gorm:"type:varchar(20);not null;default:''" json:"source_type"`
+	SourceRef  string    `gorm:"type:varchar(255);not null;default:''" json:"source_ref"`
+	Reason     string    `gorm:"type:text;not null;default:''" json:"reason"`
+	CreateTime time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP;index:idx_order_audit_log_time" json:"create_time"`
+}
+
+func (OrderAuditLog) TableName() string {
+	return "order_audit_log"
+}
+
+type OrderAuditLogResponse struct {
+	OrderID int64           `json:"order_id"`
+	Entries []OrderAuditLog `json:"entries"`
+}
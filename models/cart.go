@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Cart Redis에 사용자별 JSON으로 저장되는 장바구니. 가격은 저장하지 않고 체크아웃 시점의 상품 가격을 쓴다.
+type Cart struct {
+	UserID          int64            `json:"user_id"`
+	Items           []CartItem       `json:"items"`
+	Currency        string           `json:"currency"`
+	PaymentMethod   string           `json:"payment_method"`
+	ShippingAddress *ShippingAddress `json:"shipping_address,omitempty"`
+	CouponCodes     []string         `json:"coupon_codes"`
+	CheckoutToken   string           `json:"checkout_token,omitempty"` // 진행 중인 체크아웃의 멱등성 토큰. 장바구니가 바뀌면 초기화된다.
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+type CartItem struct {
+	ProductID int64     `json:"product_id"`
+	Quantity  int       `json:"quantity"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// IsEmpty 상품도 체크아웃 정보도 없으면 저장하지 않고 키를 지운다.
+func (c *Cart) IsEmpty() bool {
+	return len(c.Items) == 0 && c.Currency == "" && c.PaymentMethod == "" && c.ShippingAddress == nil && len(c.CouponCodes) == 0
+}
+
+// UpdateCartRequest 장바구니 전체를 교체한다.
+type UpdateCartRequest struct {
+	Items           []CartItemRequest `json:"items"`
+	Currency        string            `json:"currency"`
+	PaymentMethod   string            `json:"payment_method"`
+	ShippingAddress *ShippingAddress  `json:"shipping_address"`
+	CouponCodes     []string          `json:"coupon_codes"`
+}
+
+type CartItemRequest struct {
+	ProductID int64 `json:"product_id"`
+	Quantity  int   `json:"quantity"`
+}
+
+type UpdateCartItemRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+// CartCheckoutRequest 비어 있는 필드는 장바구니에 저장된 값을 쓴다.
+type CartCheckoutRequest struct {
+	PaymentMethod   string           `json:"payment_method"`
+	ShippingAddress *ShippingAddress `json:"shipping_address"`
+}
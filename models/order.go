@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"orderfc/infrastructure/money"
+	"strings"
+	"time"
+)
 
 type OrderDetail struct {
 	ID           int64  `gorm:"primaryKey;autoIncrement" json:"id"`
@@ -9,27 +14,80 @@ type OrderDetail struct {
 }
 
 type Order struct {
-	ID              int64       `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID          int64       `gorm:"type:bigint;not null;index:idx_orders_user_status" json:"user_id"`
-	Amount          float64     `gorm:"type:numeric;not null" json:"amount"`
-	TotalQty        int         `gorm:"type:integer;not null" json:"total_qty"`
-	PaymentMethod   string      `gorm:"type:varchar(50)" json:"payment_method"`
-	ShippingAddress string      `gorm:"type:text" json:"shipping_address"`
-	Status          int         `gorm:"type:integer;not null;index:idx_orders_user_status;index:idx_orders_status_time" json:"status"`
-	OrderDetailID   int64       `gorm:"type:bigint" json:"order_detail_id"`
-	OrderDetail     OrderDetail `gorm:"foreignKey:OrderDetailID;constraint:OnDelete:CASCADE" json:"order_detail"`
-	CreateTime      time.Time   `gorm:"type:timestamp;default:CURRENT_TIMESTAMP;index:idx_orders_status_time" json:"create_time"`
-	UpdateTime      time.Time   `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
+	ID              int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID          int64           `gorm:"type:bigint;not null;index:idx_orders_user_status;index:idx_orders_user_time,priority:1" json:"user_id"`
+	Amount          money.Amount    `gorm:"type:bigint;not null" json:"amount"` // Currency의 minor unit
+	Currency        string          `gorm:"type:varchar(3);not null;default:'KRW'" json:"currency"`
+	BaseCurrency    string          `gorm:"type:varchar(3);not null;default:''" json:"base_currency"`
+	BaseAmount      money.Amount    `gorm:"type:bigint;not null;default:0" json:"base_amount"`           // 체크아웃 시점 기준 통화 환산 금액
+	ExchangeRate    money.Rate      `gorm:"type:bigint;not null;default:100000000" json:"exchange_rate"` // BaseCurrency -> Currency 스냅샷
+	DiscountAmount  money.Amount    `gorm:"type:bigint;not null;default:0" json:"discount_amount"`       // Amount에 이미 반영된 할인 합계
+	TaxAmount       money.Amount    `gorm:"type:bigint;not null;default:0" json:"tax_amount"`            // Amount에 포함된 세금 합계
+	BaseTaxAmount   money.Amount    `gorm:"type:bigint;not null;default:0" json:"base_tax_amount"`       // TaxAmount의 기준 통화 환산
+	TotalQty        int             `gorm:"type:integer;not null" json:"total_qty"`
+	PaymentMethod   string          `gorm:"type:varchar(50)" json:"payment_method"`
+	ShippingFee     money.Amount    `gorm:"type:bigint;not null;default:0" json:"shipping_fee"` // Amount에 포함된 배송비
+	ShippingAddress string          `gorm:"type:text" json:"shipping_address"`                  // 한 줄 주소. 구조화 이전 주문은 이 값만 있다.
+	Shipping        ShippingAddress `gorm:"embedded;embeddedPrefix:shipping_" json:"shipping"`
+	Status          int             `gorm:"type:integer;not null;index:idx_orders_user_status;index:idx_orders_status_time" json:"status"`
+	OrderDetailID   int64           `gorm:"type:bigint" json:"order_detail_id"`
+	OrderDetail     OrderDetail     `gorm:"foreignKey:OrderDetailID;constraint:OnDelete:CASCADE" json:"order_detail"`
+	CreateTime      time.Time       `gorm:"type:timestamp;default:CURRENT_TIMESTAMP;index:idx_orders_status_time;index:idx_orders_user_time,priority:2" json:"create_time"`
+	UpdateTime      time.Time       `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
+
+	// order.created로 재고 예약을 요청한 금액. 결제 전 주문 변경으로 Amount가 바뀌어도 유지되며 stock.reserved와 비교한다.
+	// 0이면 이 컬럼 도입 이전 주문이라 Amount와 비교한다.
+	ReservationAmount money.Amount `gorm:"type:bigint;not null;default:0" json:"-"`
+
+	// 만료 처리에 실패한 주문은 ExpiryRetryAt까지 sweeper가 건너뛴다 (실패가 반복되는 주문이 배치를 점유하지 않도록).
+	ExpiryAttempts int        `gorm:"type:integer;not null;default:0" json:"-"`
+	ExpiryRetryAt  *time.Time `gorm:"type:timestamp" json:"-"`
+
+	// 관리자 전문 검색용. 주문/주문 라인 저장 시 RefreshOrderSearchTx가 채우며 일반 조회에서는 읽지 않는다.
+	SearchDocument string `gorm:"type:text;->:false;<-:false" json:"-"`
+	SearchVector   string `gorm:"type:tsvector;index:idx_orders_search,type:gin;->:false;<-:false" json:"-"`
+}
+
+// OrderItem 주문 라인 (order_details.products JSON의 정규화 테이블). 상품명/SKU는 주문 시점 스냅샷.
+type OrderItem struct {
+	ID          int64        `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID     int64        `gorm:"type:bigint;not null;index:idx_order_items_order" json:"order_id"`
+	ProductID   int64        `gorm:"type:bigint;not null;index:idx_order_items_product" json:"product_id"`
+	Quantity    int          `gorm:"type:integer;not null" json:"quantity"`
+	Currency    string       `gorm:"type:varchar(3);not null;default:'KRW'" json:"currency"`
+	UnitPrice   money.Amount `gorm:"type:bigint;not null" json:"unit_price"`
+	LineTotal   money.Amount `gorm:"type:bigint;not null" json:"line_total"`
+	ProductName string       `gorm:"type:varchar(255)" json:"product_name"`
+	ProductSKU  string       `gorm:"type:varchar(100)" json:"product_sku"`
+	CreateTime  time.Time    `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"create_time"`
 }
 
+// OrderTaxLine 주문 라인별 세금 (주문 통화 minor unit). 재무 부가세 대사용.
+type OrderTaxLine struct {
+	ID            int64        `gorm:"primaryKey;autoIncrement" json:"-"`
+	OrderID       int64        `gorm:"type:bigint;not null;index" json:"-"`
+	ProductID     int64        `gorm:"type:bigint;not null" json:"product_id"`
+	CategoryID    int          `gorm:"type:integer;not null;default:0" json:"category_id"`
+	Region        string       `gorm:"type:varchar(10);not null" json:"region"`
+	RateBps       int          `gorm:"type:integer;not null" json:"rate_bps"`
+	Inclusive     bool         `gorm:"type:boolean;not null;default:false" json:"inclusive"` // 세금이 상품 가격에 포함된 지역
+	TaxableAmount money.Amount `gorm:"type:bigint;not null" json:"taxable_amount"`           // 공급가액 (세금 제외)
+	TaxAmount     money.Amount `gorm:"type:bigint;not null" json:"tax_amount"`
+	CreateTime    time.Time    `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"-"`
+}
+
+// DailySalesReport 금액은 모두 Currency의 minor unit. 주문별 체크아웃 시점 기준 통화 금액(base_amount)을 합산한 뒤
+// 요청한 리포트 통화로 현재 환율을 적용해 환산한다.
 type DailySalesReport struct {
-	SaleDate          string  `json:"sale_date" gorm:"column:sale_date"`
-	OrderCount        int     `json:"order_count" gorm:"column:order_count"`
-	TotalRevenue      float64 `json:"total_revenue" gorm:"column:total_revenue"`
-	AvgOrderValue     float64 `json:"avg_order_value" gorm:"column:avg_order_value"`
-	TotalItems        int     `json:"total_items" gorm:"column:total_items"`
-	CumulativeRevenue float64 `json:"cumulative_revenue" gorm:"column:cumulative_revenue"`
-	RevenueRank       int     `json:"revenue_rank" gorm:"column:revenue_rank"`
+	SaleDate          string       `json:"sale_date" gorm:"column:sale_date"`
+	Currency          string       `json:"currency" gorm:"column:currency"`
+	OrderCount        int          `json:"order_count" gorm:"column:order_count"`
+	TotalRevenue      money.Amount `json:"total_revenue" gorm:"column:total_revenue"`
+	TotalTax          money.Amount `json:"total_tax" gorm:"column:total_tax"`
+	AvgOrderValue     money.Amount `json:"avg_order_value" gorm:"column:avg_order_value"`
+	TotalItems        int          `json:"total_items" gorm:"column:total_items"`
+	CumulativeRevenue money.Amount `json:"cumulative_revenue" gorm:"column:cumulative_revenue"`
+	RevenueRank       int          `json:"revenue_rank" gorm:"column:revenue_rank"`
 }
 
 type OrderRequestLog struct {
@@ -67,57 +125,226 @@ type OrderOutboxEvent struct {
 	UpdateTime time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
 }
 
+// ShippingAddress 구조화된 배송지. orders 테이블에는 shipping_ 접두사 컬럼으로 저장된다.
+type ShippingAddress struct {
+	Recipient  string `gorm:"type:varchar(100);default:''" json:"recipient"`
+	Phone      string `gorm:"type:varchar(30);default:''" json:"phone"`
+	Line1      string `gorm:"type:varchar(255);default:''" json:"line1"`
+	Line2      string `gorm:"type:varchar(255);default:''" json:"line2"`
+	City       string `gorm:"type:varchar(100);default:''" json:"city"`
+	PostalCode string `gorm:"type:varchar(20);default:''" json:"postal_code"`
+	Country    string `gorm:"type:varchar(2);default:''" json:"country"` // ISO 3166-1 alpha-2
+	Text       string `gorm:"-" json:"text,omitempty"`                   // 구조화 이전 클라이언트가 보낸 한 줄 주소
+}
+
+// UnmarshalJSON 구조화 이전 클라이언트는 shipping_address를 한 줄 문자열로 보낸다. 문자열이면 Text에 담는다.
+func (a *ShippingAddress) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		*a = ShippingAddress{Text: text}
+		return nil
+	}
+	type plain ShippingAddress
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// IsLegacy 한 줄 문자열로만 받은 주소.
+func (a ShippingAddress) IsLegacy() bool {
+	return a.Text != "" && a == ShippingAddress{Text: a.Text}
+}
+
+// IsZero 구조화 컬럼이 도입되기 전 주문은 모든 필드가 비어 있다.
+func (a ShippingAddress) IsZero() bool {
+	return a == ShippingAddress{}
+}
+
+// Format legacy shipping_address 컬럼과 이벤트에 쓰는 한 줄 표현.
+func (a ShippingAddress) Format() string {
+	if a.IsLegacy() {
+		return a.Text
+	}
+	parts := make([]string, 0, 5)
+	for _, part := range []string{a.Line1, a.Line2, a.City, a.PostalCode, a.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 type CheckoutItem struct {
-	ProductID int64   `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ProductID int64        `json:"product_id"`
+	Quantity  int          `json:"quantity"`
+	Price     money.Amount `json:"price"` // 단가 (minor unit)
+}
+
+// 체크아웃 상품 검증 실패 코드
+const (
+	CheckoutProblemInvalidQuantity = "invalid_quantity"
+	CheckoutProblemNotFound        = "not_found"
+	CheckoutProblemOutOfStock      = "out_of_stock"
+	CheckoutProblemPriceMismatch   = "price_mismatch"
+)
+
+// CheckoutItemProblem 422 응답의 items에 상품별로 하나씩 담긴다.
+type CheckoutItemProblem struct {
+	ProductID    int64        `json:"product_id"`
+	Code         string       `json:"code"`
+	Message      string       `json:"message"`
+	CurrentPrice money.Amount `json:"current_price,omitempty"` // price_mismatch일 때 현재 단가
 }
 
 type CheckoutRequest struct {
-	UserID           int64          `json:"user_id"`
-	Items            []CheckoutItem `json:"items"`
-	PaymentMethod    string         `json:"payment_method"`
-	ShippingAddress  string         `json:"shipping_address"`
-	IdempotencyToken string         `json:"idempotency_token"`
+	UserID           int64           `json:"user_id"`
+	Items            []CheckoutItem  `json:"items"`
+	PaymentMethod    string          `json:"payment_method"`
+	ShippingAddress  ShippingAddress `json:"shipping_address"`
+	Currency         string          `json:"currency"` // 비어 있으면 스토어 기준 통화
+	CouponCodes      []string        `json:"coupon_codes"`
+	IdempotencyToken string          `json:"idempotency_token"`
+	QuoteToken       string          `json:"quote_token"` // POST /orders/quote 결과. 만료 전까지 견적 단가/환율을 유지한다.
+}
+
+// AmendOrderRequest 결제 전(created) 주문 변경. 비어 있는 필드는 기존 값을 유지한다.
+type AmendOrderRequest struct {
+	Items           []CheckoutItem   `json:"items"`
+	PaymentMethod   string           `json:"payment_method"`
+	ShippingAddress *ShippingAddress `json:"shipping_address"`
 }
 
+type CancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+// 주문 내역 정렬 방향 (create_time, id 기준)
+const (
+	OrderHistorySortDesc = "desc"
+	OrderHistorySortAsc  = "asc"
+)
+
 type OrderHistoryParam struct {
-	UserID int64 `json:"user_id"`
-	Status int   `json:"status"`
+	UserID        int64         `json:"user_id"`
+	Status        *int          `json:"status"`
+	From          *time.Time    `json:"from"`
+	To            *time.Time    `json:"to"`
+	MinAmount     *money.Amount `json:"min_amount"`
+	MaxAmount     *money.Amount `json:"max_amount"`
+	PaymentMethod string        `json:"payment_method"`
+	ProductID     *int64        `json:"product_id"`
+	Sort          string        `json:"sort"`
+	Cursor        string        `json:"cursor"`
+	Limit         int           `json:"limit"`
+
+	// After 디코딩된 cursor. 이 위치 다음 행부터 조회한다.
+	After *OrderHistoryCursor `json:"-"`
+}
+
+// OrderHistoryCursor keyset 페이지네이션 위치. 클라이언트에는 base64 인코딩된 불투명 문자열로 전달된다.
+type OrderHistoryCursor struct {
+	CreateTime time.Time `json:"t"`
+	ID         int64     `json:"id"`
+	Sort       string    `json:"s"`
+}
+
+type OrderHistoryPage struct {
+	Orders     []OrderHistoryResponse `json:"orders"`
+	NextCursor string                 `json:"next_cursor"`
 }
 
 type OrderHistoryResponse struct {
-	OrderID         int64           `json:"order_id"`
-	TotalAmount     float64         `json:"total_amount"`
-	TotalQty        int             `json:"total_qty"`
-	PaymentMethod   string          `json:"payment_method"`
-	ShippingAddress string          `json:"shipping_address"`
-	Products        []CheckoutItem  `json:"products"`
-	History         []StatusHistory `json:"history"`
-	Status          string          `json:"status"`
+	OrderID         int64            `json:"order_id"`
+	UserID          int64            `json:"user_id"`
+	TotalAmount     money.Amount     `json:"total_amount"`
+	Currency        string           `json:"currency"`
+	DiscountAmount  money.Amount     `json:"discount_amount"`
+	Discounts       []OrderDiscount  `json:"discounts"`
+	TaxAmount       money.Amount     `json:"tax_amount"`
+	TaxLines        []OrderTaxLine   `json:"tax_lines"`
+	Shipment        *Shipment        `json:"shipment,omitempty"` // 단건 조회에서만 채운다
+	ShippingFee     money.Amount     `json:"shipping_fee"`
+	TotalQty        int              `json:"total_qty"`
+	PaymentMethod   string           `json:"payment_method"`
+	ShippingAddress string           `json:"shipping_address"`
+	Shipping        *ShippingAddress `json:"shipping,omitempty"` // 구조화 이전 주문은 생략
+	Products        []CheckoutItem   `json:"products"`
+	History         []StatusHistory  `json:"history"`
+	Status          string           `json:"status"`
+	CreateTime      time.Time        `json:"create_time"`
 }
 
 type StatusHistory struct {
 	Status    int    `json:"status"`
 	Timestamp string `json:"timestamp"`
+	Actor     string `json:"actor,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// UnmarshalJSON 초기 버전 이력은 timestamp 대신 time 키로 저장되어 있어 함께 읽는다.
+func (h *StatusHistory) UnmarshalJSON(data []byte) error {
+	type statusHistory StatusHistory
+	aux := struct {
+		statusHistory
+		Time string `json:"time"`
+	}{}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*h = StatusHistory(aux.statusHistory)
+	if h.Timestamp == "" {
+		h.Timestamp = aux.Time
+	}
+	return nil
 }
 
 type OrderHistoryResult struct {
 	Id              int64 `json:"id" gorm:"column:id"`
-	Amount          float64
+	UserID          int64
+	Amount          money.Amount
+	Currency        string
+	DiscountAmount  money.Amount
+	TaxAmount       money.Amount
+	ShippingFee     money.Amount
 	TotalQty        int
 	Status          int
 	PaymentMethod   string
 	ShippingAddress string
-	Products        string `gorm:"column:products"`
-	OrderHistory    string `gorm:"column:order_history"`
+	Shipping        ShippingAddress `gorm:"embedded;embeddedPrefix:shipping_"`
+	Products        string          `gorm:"column:products"`
+	OrderHistory    string          `gorm:"column:order_history"`
+	CreateTime      time.Time
 }
 
+// OrderCreatedSchemaVersion order.created 스키마 버전. v1(schema_version 없음)은 금액이 major unit 소수였고,
+// v2부터 Currency의 minor unit 정수다.
+const OrderCreatedSchemaVersion = 2
+
 type OrderCreatedEvent struct {
-	OrderID         int64         `json:"order_id"`
-	UserID          int64         `json:"user_id"`
-	TotalAmount     float64       `json:"total_amount"`
-	PaymentMethod   string        `json:"payment_method"`
-	ShippingAddress string        `json:"shipping_address"`
-	Products        []ProductItem `json:"products"`
+	SchemaVersion   int             `json:"schema_version"`
+	OrderID         int64           `json:"order_id"`
+	UserID          int64           `json:"user_id"`
+	TotalAmount     money.Amount    `json:"total_amount"`
+	Currency        string          `json:"currency"`
+	DiscountAmount  money.Amount    `json:"discount_amount"`
+	Discounts       []OrderDiscount `json:"discounts"`
+	TaxAmount       money.Amount    `json:"tax_amount"`
+	ShippingFee     money.Amount    `json:"shipping_fee"`
+	PaymentMethod   string          `json:"payment_method"`
+	ShippingAddress string          `json:"shipping_address"` // 한 줄 표현 (기존 소비자 호환)
+	Shipping        ShippingAddress `json:"shipping"`
+	Products        []ProductItem   `json:"products"`
+}
+
+// OrderStatusChangedEvent order.status_changed 페이로드 (스키마 v1). 모든 상태 전이마다 outbox로 발행된다.
+type OrderStatusChangedEvent struct {
+	SchemaVersion int       `json:"schema_version"`
+	OrderID       int64     `json:"order_id"`
+	UserID        int64     `json:"user_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	Actor         string    `json:"actor"`
+	Reason        string    `json:"reason,omitempty"`
+	EventTime     time.Time `json:"event_time"`
 }
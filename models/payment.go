@@ -1,6 +1,33 @@
 package models
 
+import (
+	"orderfc/infrastructure/money"
+	"time"
+)
+
 type PaymentUpdateStatusEvent struct {
 	OrderID int64  `json:"order_id"`
 	Status  string `json:"status"`
 }
+
+// PaymentRefundRequestedEvent payment.refund_requested 페이로드 (스키마 v1). 주문이 취소된 뒤 결제가 완료되면 saga가 발행한다.
+type PaymentRefundRequestedEvent struct {
+	SchemaVersion int          `json:"schema_version"`
+	OrderID       int64        `json:"order_id"`
+	UserID        int64        `json:"user_id"`
+	Amount        money.Amount `json:"amount"` // Currency의 minor unit
+	Currency      string       `json:"currency"`
+	Reason        string       `json:"reason"`
+	EventTime     time.Time    `json:"event_time"`
+}
+
+// PaymentRequestedEvent payment.requested 페이로드 (스키마 v1). 재고 예약 후 saga가 발행한다.
+type PaymentRequestedEvent struct {
+	SchemaVersion int          `json:"schema_version"`
+	OrderID       int64        `json:"order_id"`
+	UserID        int64        `json:"user_id"`
+	Amount        money.Amount `json:"amount"` // Currency의 minor unit
+	Currency      string       `json:"currency"`
+	PaymentMethod string       `json:"payment_method"`
+	EventTime     time.Time    `json:"event_time"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"orderfc/infrastructure/money"
+	"time"
+)
 
 type ProductInfo struct {
 	Product Product `json:"product"`
@@ -9,10 +13,12 @@ type ProductInfo struct {
 type Product struct {
 	ID          int64   `json:"id"`
 	Name        string  `json:"name"`
+	SKU         string  `json:"sku"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Price       float64 `json:"price"` // productfc 응답 (major unit). 주문에서는 money.FromMajor로 변환해 사용한다.
 	Stock       int     `json:"stock"`
 	CategoryID  int     `json:"category_id"`
+	Weight      int     `json:"weight"` // 그램 단위. productfc가 제공하지 않으면 0 (무게 요금 미적용)
 }
 
 // ProductStockUpdatedEvent — stock.updated / stock.rollback 발행에 공통 필드 (스키마 v1).
@@ -29,12 +35,32 @@ type ProductItem struct {
 	Quantity  int   `json:"quantity"`
 }
 
+// StockReservationMinorUnitVersion 이 버전부터 stock.reserved/rejected의 total_amount가 주문 통화 minor unit 정수다.
+// 이전 버전(productfc 구버전)은 major unit 소수를 보낸다.
+const StockReservationMinorUnitVersion = 2
+
 type StockReservationEvent struct {
 	SchemaVersion int           `json:"schema_version"`
 	OrderID       int64         `json:"order_id"`
 	UserID        int64         `json:"user_id"`
-	TotalAmount   float64       `json:"total_amount"`
+	TotalAmount   json.Number   `json:"total_amount"` // 단위는 SchemaVersion에 따라 다르다. ReservedAmount로 읽는다.
 	Products      []ProductItem `json:"products"`
 	Reason        string        `json:"reason,omitempty"`
 	EventTime     time.Time     `json:"event_time"`
 }
+
+// ReservedAmount total_amount를 주문 통화 minor unit으로 변환한다.
+func (e StockReservationEvent) ReservedAmount(currency string) (money.Amount, error) {
+	if e.SchemaVersion >= StockReservationMinorUnitVersion {
+		return money.ParseMinor(e.TotalAmount.String())
+	}
+	return money.ParseDecimal(e.TotalAmount.String(), currency)
+}
+
+func (e StockReservationEvent) ProductIDs() []int64 {
+	ids := make([]int64, 0, len(e.Products))
+	for _, product := range e.Products {
+		ids = append(ids, product.ProductID)
+	}
+	return ids
+}
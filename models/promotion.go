@@ -0,0 +1,62 @@
+package models
+
+import (
+	"orderfc/infrastructure/money"
+	"time"
+)
+
+const (
+	PromotionTypePercentage = "percentage"
+	PromotionTypeFixed      = "fixed"
+	PromotionTypeBuyXGetY   = "buy_x_get_y"
+)
+
+const (
+	CouponRedemptionStatusActive   = "active"
+	CouponRedemptionStatusReleased = "released"
+)
+
+// Promotion 쿠폰/프로모션 정의. 금액 필드(AmountOff, MinSpend)는 스토어 기준 통화 minor unit이다.
+type Promotion struct {
+	ID             int64        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code           string       `gorm:"type:varchar(50);not null;uniqueIndex" json:"code"` // 대문자로 저장 (입력 코드는 대문자로 정규화해 비교)
+	Name           string       `gorm:"type:varchar(255)" json:"name"`
+	Type           string       `gorm:"type:varchar(20);not null" json:"type"`
+	AutoApply      bool         `gorm:"not null;default:false" json:"auto_apply"`           // 코드 입력 없이 자동 적용
+	PercentBps     int          `gorm:"type:integer;not null;default:0" json:"percent_bps"` // 1000 = 10%
+	AmountOff      money.Amount `gorm:"type:bigint;not null;default:0" json:"amount_off"`
+	ProductID      int64        `gorm:"type:bigint;not null;default:0" json:"product_id"` // buy_x_get_y 대상 상품
+	BuyQty         int          `gorm:"type:integer;not null;default:0" json:"buy_qty"`
+	GetQty         int          `gorm:"type:integer;not null;default:0" json:"get_qty"`
+	MinSpend       money.Amount `gorm:"type:bigint;not null;default:0" json:"min_spend"`
+	MaxRedemptions int          `gorm:"type:integer;not null;default:0" json:"max_redemptions"` // 0 = 무제한
+	PerUserLimit   int          `gorm:"type:integer;not null;default:0" json:"per_user_limit"`  // 0 = 무제한
+	RedeemedCount  int          `gorm:"type:integer;not null;default:0" json:"redeemed_count"`
+	StartTime      time.Time    `gorm:"type:timestamp;not null" json:"start_time"`
+	ExpireTime     time.Time    `gorm:"type:timestamp;not null" json:"expire_time"`
+	Active         bool         `gorm:"not null;default:true" json:"active"`
+	CreateTime     time.Time    `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime     time.Time    `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+// CouponRedemption 주문별 프로모션 사용 기록. 주문 취소 시 released로 바뀌고 사용 횟수가 반환된다.
+type CouponRedemption struct {
+	ID          int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PromotionID int64     `gorm:"type:bigint;not null;index:idx_coupon_redemptions_promotion_user" json:"promotion_id"`
+	UserID      int64     `gorm:"type:bigint;not null;index:idx_coupon_redemptions_promotion_user" json:"user_id"`
+	OrderID     int64     `gorm:"type:bigint;not null;index" json:"order_id"`
+	Status      string    `gorm:"type:varchar(20);not null;default:'active'" json:"status"`
+	CreateTime  time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime  time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+// OrderDiscount 주문에 적용된 할인 내역 (주문 통화 minor unit).
+type OrderDiscount struct {
+	ID          int64        `gorm:"primaryKey;autoIncrement" json:"-"`
+	OrderID     int64        `gorm:"type:bigint;not null;index" json:"-"`
+	PromotionID int64        `gorm:"type:bigint;not null" json:"promotion_id"`
+	Code        string       `gorm:"type:varchar(50);not null" json:"code"`
+	Type        string       `gorm:"type:varchar(20);not null" json:"type"`
+	Amount      money.Amount `gorm:"type:bigint;not null" json:"amount"`
+	CreateTime  time.Time    `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"-"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"orderfc/infrastructure/money"
+	"time"
+)
+
+// QuoteResponse 체크아웃 미리보기. 아무것도 저장하지 않으며, quote_token을 체크아웃에 넘기면 만료 전까지 견적 단가가 유지된다.
+type QuoteResponse struct {
+	QuoteToken     string          `json:"quote_token"`
+	ExpiresAt      time.Time       `json:"expires_at"`
+	Currency       string          `json:"currency"`
+	Items          []QuoteItem     `json:"items"`
+	Subtotal       money.Amount    `json:"subtotal"`
+	DiscountAmount money.Amount    `json:"discount_amount"`
+	Discounts      []OrderDiscount `json:"discounts"`
+	TaxAmount      money.Amount    `json:"tax_amount"`
+	TaxLines       []OrderTaxLine  `json:"tax_lines"`
+	ShippingFee    money.Amount    `json:"shipping_fee"`
+	TotalAmount    money.Amount    `json:"total_amount"`
+}
+
+type QuoteItem struct {
+	ProductID   int64        `json:"product_id"`
+	ProductName string       `json:"product_name"`
+	Quantity    int          `json:"quantity"`
+	UnitPrice   money.Amount `json:"unit_price"`
+	LineTotal   money.Amount `json:"line_total"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "orderfc/infrastructure/money"
+
+// 재주문 불가 사유
+const (
+	ReorderUnavailableNotFound   = "not_found"
+	ReorderUnavailableOutOfStock = "out_of_stock"
+)
+
+// ReorderRequest 비어 있는 결제 수단/배송지는 원 주문 값을 쓴다. place_order가 false면 미리보기만 반환한다.
+type ReorderRequest struct {
+	PlaceOrder       bool             `json:"place_order"`
+	PaymentMethod    string           `json:"payment_method"`
+	ShippingAddress  *ShippingAddress `json:"shipping_address"`
+	CouponCodes      []string         `json:"coupon_codes"`
+	IdempotencyToken string           `json:"idempotency_token"`
+}
+
+type ReorderItem struct {
+	ProductID     int64        `json:"product_id"`
+	ProductName   string       `json:"product_name"`
+	Quantity      int          `json:"quantity"`
+	PreviousPrice money.Amount `json:"previous_price"`
+	CurrentPrice  money.Amount `json:"current_price"` // 현재 환율 기준 주문 통화 단가
+	PriceChanged  bool         `json:"price_changed"`
+	Available     bool         `json:"available"`
+	Unavailable   string       `json:"unavailable_reason,omitempty"`
+}
+
+// ReorderPreview checkout은 주문 가능한 상품만 현재 가격으로 채운 체크아웃 요청으로, 그대로 POST /orders에 보낼 수 있다.
+// 금액은 체크아웃과 같은 계산 결과이며 주문 가능한 상품이 없으면 모두 0이다.
+type ReorderPreview struct {
+	SourceOrderID       int64           `json:"source_order_id"`
+	Currency            string          `json:"currency"`
+	Items               []ReorderItem   `json:"items"`
+	Subtotal            money.Amount    `json:"subtotal"` // 주문 가능한 상품 합계 (할인/세금/배송비 전)
+	DiscountAmount      money.Amount    `json:"discount_amount"`
+	Discounts           []OrderDiscount `json:"discounts"`
+	TaxAmount           money.Amount    `json:"tax_amount"`
+	TaxLines            []OrderTaxLine  `json:"tax_lines"`
+	ShippingFee         money.Amount    `json:"shipping_fee"`
+	TotalAmount         money.Amount    `json:"total_amount"` // 체크아웃 시 결제할 금액
+	HasUnavailableItems bool            `json:"has_unavailable_items"`
+	HasPriceChanges     bool            `json:"has_price_changes"`
+	Checkout            CheckoutRequest `json:"checkout"`
+	OrderID             int64           `json:"order_id,omitempty"` // place_order로 생성된 주문
+}
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+const (
+	SagaStatusRunning      = "running"
+	SagaStatusCompensating = "compensating"
+	SagaStatusCompleted    = "completed"
+	SagaStatusCompensated  = "compensated"
+	SagaStatusFailed       = "failed" // 보상도 불가능한 상태. 수동 처리 필요
+)
+
+// 정방향 step
+const (
+	SagaStepReserveStock   = "reserve_stock"
+	SagaStepRequestPayment = "request_payment"
+	SagaStepConfirmOrder   = "confirm_order"
+)
+
+// 보상 step
+const (
+	SagaStepCancelOrder   = "cancel_order"
+	SagaStepReleaseStock  = "release_stock"
+	SagaStepRefundPayment = "refund_payment" // 취소 이후 도착한 결제 완료를 환불 요청한다
+)
+
+const (
+	SagaStepStatusPending   = "pending" // 실행 대기. orchestrator 또는 recovery worker가 실행한다
+	SagaStepStatusWaiting   = "waiting" // 커맨드 발행 후 응답 이벤트 대기
+	SagaStepStatusSucceeded = "succeeded"
+	SagaStepStatusFailed    = "failed"
+	SagaStepStatusAborted   = "aborted" // 보상 시작으로 더 이상 진행하지 않음
+)
+
+// OrderSaga 주문별 체크아웃 saga 상태. 체크아웃 트랜잭션에서 생성된다.
+type OrderSaga struct {
+	ID          int64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID     int64           `gorm:"type:bigint;not null;uniqueIndex" json:"order_id"`
+	Status      string          `gorm:"type:varchar(20);not null;index:idx_order_sagas_status_time" json:"status"`
+	CurrentStep string          `gorm:"type:varchar(30);not null" json:"current_step"`
+	LastError   string          `gorm:"type:text" json:"last_error"`
+	Steps       []OrderSagaStep `gorm:"foreignKey:SagaID;constraint:OnDelete:CASCADE" json:"steps"`
+	CreateTime  time.Time       `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime  time.Time       `gorm:"type:timestamp;default:CURRENT_TIMESTAMP;index:idx_order_sagas_status_time" json:"update_time"`
+
+	// step 실행이 연속으로 실패한 횟수와 다음 recovery 시각. 실행 트랜잭션 밖에서 기록되고 saga가 저장되면 초기화된다.
+	RecoveryAttempts int        `gorm:"type:integer;not null;default:0" json:"recovery_attempts"`
+	NextRetryAt      *time.Time `gorm:"type:timestamp" json:"next_retry_at,omitempty"`
+}
+
+type OrderSagaStep struct {
+	ID           int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	SagaID       int64     `gorm:"type:bigint;not null;index" json:"saga_id"`
+	Step         string    `gorm:"type:varchar(30);not null" json:"step"`
+	Compensation bool      `gorm:"not null;default:false" json:"compensation"`
+	Status       string    `gorm:"type:varchar(20);not null" json:"status"`
+	Detail       string    `gorm:"type:text" json:"detail"`
+	CreateTime   time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime   time.Time `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
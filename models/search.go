@@ -0,0 +1,30 @@
+package models
+
+import (
+	"orderfc/infrastructure/money"
+	"time"
+)
+
+type OrderSearchParam struct {
+	Query  string `json:"q"`
+	UserID int64  `json:"user_id"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// OrderSearchResult 전문 검색 결과 한 건. Headline은 HTML 이스케이프된 발췌문으로, 일치한 부분만 <mark>로 감싼다.
+type OrderSearchResult struct {
+	OrderID    int64        `json:"order_id"`
+	UserID     int64        `json:"user_id"`
+	Status     int          `json:"status"`
+	Amount     money.Amount `json:"amount"`
+	Currency   string       `json:"currency"`
+	CreateTime time.Time    `json:"create_time"`
+	Rank       float64      `json:"rank"`
+	Headline   string       `json:"headline"`
+}
+
+type OrderSearchPage struct {
+	Results    []OrderSearchResult `json:"results"`
+	NextOffset *int                `json:"next_offset"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Shipment 주문당 하나의 배송 레코드. 관리자 출고 처리 또는 shipment.* 이벤트로 생성/갱신된다.
+type Shipment struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID        int64      `gorm:"type:bigint;not null;uniqueIndex" json:"order_id"`
+	Carrier        string     `gorm:"type:varchar(50);not null;index:idx_shipments_tracking" json:"carrier"`
+	TrackingNumber string     `gorm:"type:varchar(100);not null;index:idx_shipments_tracking" json:"tracking_number"`
+	ShippedAt      *time.Time `gorm:"type:timestamp" json:"shipped_at"`
+	DeliveredAt    *time.Time `gorm:"type:timestamp" json:"delivered_at"`
+	CreateTime     time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"create_time"`
+	UpdateTime     time.Time  `gorm:"type:timestamp;default:CURRENT_TIMESTAMP" json:"update_time"`
+}
+
+type ShipOrderRequest struct {
+	Carrier        string     `json:"carrier"`
+	TrackingNumber string     `json:"tracking_number"`
+	ShippedAt      *time.Time `json:"shipped_at"` // 비어 있으면 요청 시각
+}
+
+// ShipmentEvent shipment.shipped / shipment.delivered 페이로드 (물류 연동 서비스 발행).
+type ShipmentEvent struct {
+	OrderID        int64     `json:"order_id"`
+	Carrier        string    `json:"carrier"`
+	TrackingNumber string    `json:"tracking_number"`
+	EventTime      time.Time `json:"event_time"`
+}
@@ -0,0 +1,120 @@
+package productclient
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBreakerStateMachine(t *testing.T) {
+	type step struct {
+		op        string // allow, success, failure, release
+		advance   time.Duration
+		wantAllow bool // allow일 때만 비교
+		wantState int
+	}
+	tests := []struct {
+		name      string
+		threshold int
+		steps     []step
+	}{
+		{
+			name:      "opens at threshold and blocks until cooldown",
+			threshold: 2,
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", wantAllow: false, wantState: breakerOpen},
+				{op: "allow", advance: 29 * time.Second, wantAllow: false, wantState: breakerOpen},
+				{op: "allow", advance: time.Second, wantAllow: true, wantState: breakerHalfOpen},
+			},
+		},
+		{
+			name:      "success resets consecutive failures",
+			threshold: 2,
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "success", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "allow", wantAllow: true, wantState: breakerClosed},
+			},
+		},
+		{
+			name:      "half-open allows a single probe and closes on success",
+			threshold: 1,
+			steps: []step{
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", advance: 30 * time.Second, wantAllow: true, wantState: breakerHalfOpen},
+				{op: "allow", wantAllow: false, wantState: breakerHalfOpen},
+				{op: "success", wantState: breakerClosed},
+				{op: "allow", wantAllow: true, wantState: breakerClosed},
+			},
+		},
+		{
+			name:      "failed probe reopens for another cooldown",
+			threshold: 3,
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", advance: 30 * time.Second, wantAllow: true, wantState: breakerHalfOpen},
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", advance: 10 * time.Second, wantAllow: false, wantState: breakerOpen},
+				{op: "allow", advance: 20 * time.Second, wantAllow: true, wantState: breakerHalfOpen},
+			},
+		},
+		{
+			name:      "released probe lets the next request probe",
+			threshold: 1,
+			steps: []step{
+				{op: "failure", wantState: breakerOpen},
+				{op: "allow", advance: 30 * time.Second, wantAllow: true, wantState: breakerHalfOpen},
+				{op: "release", wantState: breakerHalfOpen},
+				{op: "allow", wantAllow: true, wantState: breakerHalfOpen},
+				{op: "allow", wantAllow: false, wantState: breakerHalfOpen},
+			},
+		},
+		{
+			name:      "release keeps the closed failure count",
+			threshold: 2,
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "release", wantState: breakerClosed},
+				{op: "failure", wantState: breakerOpen},
+			},
+		},
+		{
+			name:      "disabled breaker never opens",
+			threshold: -1,
+			steps: []step{
+				{op: "failure", wantState: breakerClosed},
+				{op: "failure", wantState: breakerClosed},
+				{op: "allow", wantAllow: true, wantState: breakerClosed},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+			b := newBreaker(tt.threshold, 30*time.Second)
+			b.now = func() time.Time { return now }
+			for i, s := range tt.steps {
+				now = now.Add(s.advance)
+				switch s.op {
+				case "allow":
+					if got := b.allow(); got != s.wantAllow {
+						t.Fatalf("step %d allow = %v, want %v", i, got, s.wantAllow)
+					}
+				case "success":
+					b.success()
+				case "failure":
+					b.failure()
+				case "release":
+					b.release()
+				}
+				if b.state != s.wantState {
+					t.Fatalf("step %d (%s) state = %d, want %d", i, s.op, b.state, s.wantState)
+				}
+			}
+		})
+	}
+}
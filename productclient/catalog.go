@@ -0,0 +1,39 @@
+package productclient
+
+import (
+	"context"
+	"fmt"
+	"orderfc/config"
+	"orderfc/models"
+)
+
+// product.transport 값
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// ProductCatalog productfc 상품 조회. 실패는 ErrNotFound / ErrUnavailable / ErrBadResponse로 감싸서 돌려준다.
+type ProductCatalog interface {
+	GetProduct(ctx context.Context, productID int64) (models.Product, error)
+	// GetProducts 반환 map에 없는 ID는 존재하지 않는 상품이다. 배치 조회를 지원하지 않으면 ErrBatchUnsupported.
+	GetProducts(ctx context.Context, productIDs []int64) (map[int64]models.Product, error)
+}
+
+// NewCatalog product.transport에 맞는 구현을 만든다. 비어 있으면 HTTP.
+func NewCatalog(cfg config.ProductConfig) (ProductCatalog, error) {
+	switch cfg.Transport {
+	case "", TransportHTTP:
+		return NewHTTPClient(cfg), nil
+	case TransportGRPC:
+		return NewGRPCClient(cfg)
+	default:
+		return nil, fmt.Errorf("unknown product transport %q", cfg.Transport)
+	}
+}
+
+var (
+	_ ProductCatalog = (*HTTPClient)(nil)
+	_ ProductCatalog = (*GRPCClient)(nil)
+	_ ProductCatalog = (*FakeCatalog)(nil)
+)
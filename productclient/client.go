@@ -0,0 +1,251 @@
+// Package productclient productfc 상품 조회. HTTP/gRPC 구현 모두 타임아웃, 지터를 섞은 재시도, 서킷 브레이커를
+// 적용하고 실패를 ErrNotFound / ErrUnavailable / ErrBadResponse로 구분해 돌려준다.
+package productclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"orderfc/config"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	ErrNotFound    = errors.New("product not found")
+	ErrUnavailable = errors.New("product service unavailable")
+	ErrBadResponse = errors.New("unexpected response from product service")
+	// ErrBatchUnsupported 배치 조회 API가 없는 productfc. 호출 측은 단건 조회로 대체한다.
+	ErrBatchUnsupported = errors.New("product batch lookup is not supported")
+
+	errCircuitOpen = errors.New("circuit open")
+)
+
+const (
+	defaultTimeout          = 2 * time.Second
+	defaultMaxRetries       = 2
+	defaultRetryBaseDelay   = 100 * time.Millisecond
+	defaultRetryMaxDelay    = time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+var (
+	clientRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "product_client",
+			Name:      "requests_total",
+			Help:      "Product service calls by transport, operation and outcome (ok, not_found, unavailable, bad_response, circuit_open)",
+		},
+		[]string{"transport", "operation", "outcome"},
+	)
+	clientDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "commerce",
+			Subsystem: "product_client",
+			Name:      "request_duration_seconds",
+			Help:      "Product service call duration including retries",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+		[]string{"transport", "operation"},
+	)
+	clientRetries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "commerce",
+			Subsystem: "product_client",
+			Name:      "retries_total",
+			Help:      "Product service retry attempts by transport and operation",
+		},
+		[]string{"transport", "operation"},
+	)
+	breakerState = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "commerce",
+			Subsystem: "product_client",
+			Name:      "breaker_state",
+			Help:      "Product service circuit breaker state (0 closed, 1 open, 2 half-open)",
+		},
+	)
+)
+
+// StatusError productfc가 예상하지 못한 상태 코드로 응답했을 때의 원인. ErrUnavailable 또는 ErrBadResponse로 감싸진다.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("product service returned status %d", e.StatusCode)
+}
+
+// caller 전송 방식과 무관한 재시도/브레이커/메트릭/트레이싱. 각 구현은 한 번의 시도만 attemptFunc로 넘긴다.
+type caller struct {
+	transport      string
+	timeout        time.Duration
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+	breaker        *breaker
+	tracer         trace.Tracer
+}
+
+// attemptFunc 재시도해도 되는 실패(네트워크 오류, 타임아웃, 과부하)면 retryable을 true로 돌려준다.
+type attemptFunc func(ctx context.Context) (retryable bool, err error)
+
+func newCaller(transport string, cfg config.ProductConfig) *caller {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	maxRetries := cfg.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	} else if maxRetries == 0 {
+		maxRetries = defaultMaxRetries
+	}
+	baseDelay := cfg.RetryBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = defaultRetryBaseDelay
+	}
+	maxDelay := cfg.RetryMaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+	threshold := cfg.BreakerThreshold
+	if threshold == 0 {
+		threshold = defaultBreakerThreshold
+	}
+	cooldown := cfg.BreakerCooldown
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+
+	return &caller{
+		transport:      transport,
+		timeout:        timeout,
+		maxRetries:     maxRetries,
+		retryBaseDelay: baseDelay,
+		retryMaxDelay:  maxDelay,
+		breaker:        newBreaker(threshold, cooldown),
+		tracer:         otel.Tracer("orderfc/productclient"),
+	}
+}
+
+// call 재시도 가능한 실패만 재시도한다. 브레이커는 호출 단위로 성공/실패를 센다.
+func (c *caller) call(ctx context.Context, operation string, attempt attemptFunc, attrs ...attribute.KeyValue) (err error) {
+	start := time.Now()
+	attrs = append(attrs, attribute.String("product_client.transport", c.transport))
+	ctx, span := c.tracer.Start(ctx, "productclient."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer func() {
+		outcome := outcomeOf(err)
+		clientRequests.WithLabelValues(c.transport, operation, outcome).Inc()
+		clientDuration.WithLabelValues(c.transport, operation).Observe(time.Since(start).Seconds())
+		span.SetAttributes(attribute.String("product_client.outcome", outcome))
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if !c.breaker.allow() {
+		return fmt.Errorf("%w: %w", ErrUnavailable, errCircuitOpen)
+	}
+
+	for n := 0; ; n++ {
+		var retryable bool
+		retryable, err = c.attempt(ctx, attempt, c.maxRetries-n+1)
+		if err == nil || !retryable || n >= c.maxRetries {
+			break
+		}
+		clientRetries.WithLabelValues(c.transport, operation).Inc()
+		span.AddEvent("retry", trace.WithAttributes(attribute.Int("attempt", n+1)))
+		if waitErr := c.backoff(ctx, n); waitErr != nil {
+			err = fmt.Errorf("%w: %v", ErrUnavailable, waitErr)
+			break
+		}
+	}
+
+	switch {
+	case err != nil && errors.Is(ctx.Err(), context.Canceled):
+		// 호출자가 명시적으로 취소한 요청은 productfc 상태와 무관하므로 브레이커에 세지 않는다.
+		// 호출자 deadline이 지난 경우는 productfc가 제때 응답하지 않은 것이라 실패로 센다.
+		c.breaker.release()
+	case errors.Is(err, ErrUnavailable):
+		c.breaker.failure()
+	default:
+		c.breaker.success()
+	}
+	return err
+}
+
+// attempt 시도마다 timeout을 따로 건다.
+func (c *caller) attempt(ctx context.Context, attempt attemptFunc, attemptsLeft int) (bool, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, c.attemptTimeout(ctx, attemptsLeft))
+	defer cancel()
+	retryable, err := attempt(attemptCtx)
+	// 호출자가 취소한 요청은 재시도하지 않는다.
+	if retryable && ctx.Err() != nil {
+		retryable = false
+	}
+	return retryable, err
+}
+
+// attemptTimeout 호출자 deadline이 있으면 남은 시간을 남은 시도 수로 나눠, 응답 없는 productfc에 한 시도가
+// 요청 deadline을 다 써서 재시도 기회가 사라지지 않게 한다.
+func (c *caller) attemptTimeout(ctx context.Context, attemptsLeft int) time.Duration {
+	timeout := c.timeout
+	if deadline, ok := ctx.Deadline(); ok && attemptsLeft > 1 {
+		if share := time.Until(deadline) / time.Duration(attemptsLeft); share < timeout {
+			timeout = share
+		}
+	}
+	return timeout
+}
+
+// backoff full jitter: [0, min(maxDelay, base*2^attempt)) 사이에서 무작위로 기다린다. 요청 deadline이 있으면
+// 대기가 남은 시도의 몫을 넘지 않게 줄인다.
+func (c *caller) backoff(ctx context.Context, attempt int) error {
+	delay := c.retryBaseDelay << attempt
+	if delay <= 0 || delay > c.retryMaxDelay {
+		delay = c.retryMaxDelay
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if share := time.Until(deadline) / time.Duration(c.maxRetries-attempt+1); share < delay {
+			delay = max(share, time.Millisecond)
+		}
+	}
+	timer := time.NewTimer(rand.N(delay) + time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func outcomeOf(err error) string {
+	switch {
+	case err == nil:
+		return "ok"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, errCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, ErrUnavailable):
+		return "unavailable"
+	default:
+		return "bad_response"
+	}
+}
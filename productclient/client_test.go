@@ -0,0 +1,125 @@
+package productclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"orderfc/config"
+	"testing"
+	"time"
+)
+
+func newTestCaller(threshold int) *caller {
+	return newCaller("test", config.ProductConfig{
+		Timeout:          2 * time.Second,
+		MaxRetries:       2,
+		RetryBaseDelay:   time.Millisecond,
+		RetryMaxDelay:    time.Millisecond,
+		BreakerThreshold: threshold,
+		BreakerCooldown:  time.Minute,
+	})
+}
+
+func TestCallerRetryClassification(t *testing.T) {
+	unavailable := fmt.Errorf("%w: connection refused", ErrUnavailable)
+	badResponse := fmt.Errorf("%w: status 400", ErrBadResponse)
+	tests := []struct {
+		name         string
+		results      []error // 시도별 결과. 마지막 값이 이후 시도에도 쓰인다
+		retryable    bool
+		wantAttempts int
+		wantErr      error
+		wantFailures int
+	}{
+		{name: "success", results: []error{nil}, wantAttempts: 1, wantFailures: 0},
+		{name: "retry then success", results: []error{unavailable, nil}, retryable: true, wantAttempts: 2, wantFailures: 0},
+		{name: "retries exhausted", results: []error{unavailable}, retryable: true, wantAttempts: 3, wantErr: ErrUnavailable, wantFailures: 1},
+		{name: "not retryable unavailable", results: []error{unavailable}, wantAttempts: 1, wantErr: ErrUnavailable, wantFailures: 1},
+		{name: "bad response", results: []error{badResponse}, wantAttempts: 1, wantErr: ErrBadResponse, wantFailures: 0},
+		{name: "not found", results: []error{ErrNotFound}, wantAttempts: 1, wantErr: ErrNotFound, wantFailures: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCaller(10)
+			attempts := 0
+			err := c.call(context.Background(), "get_product", func(ctx context.Context) (bool, error) {
+				result := tt.results[min(attempts, len(tt.results)-1)]
+				attempts++
+				return result != nil && tt.retryable, result
+			})
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if c.breaker.failures != tt.wantFailures {
+				t.Errorf("breaker failures = %d, want %d", c.breaker.failures, tt.wantFailures)
+			}
+		})
+	}
+}
+
+// hang 응답 없는 productfc. 시도 context가 끝날 때까지 기다린다.
+func hang(ctx context.Context) (bool, error) {
+	<-ctx.Done()
+	return true, fmt.Errorf("%w: %v", ErrUnavailable, ctx.Err())
+}
+
+// 요청 deadline이 시도 timeout보다 짧아도 재시도 기회를 나눠 쓰고, 응답 없는 productfc는 브레이커 실패로 센다.
+func TestCallerCountsHangWithinRequestDeadline(t *testing.T) {
+	c := newTestCaller(1)
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := c.call(ctx, "get_product", func(ctx context.Context) (bool, error) {
+		attempts++
+		return hang(ctx)
+	})
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3 within the request deadline", attempts)
+	}
+	if c.breaker.state != breakerOpen {
+		t.Errorf("breaker state = %d, want open", c.breaker.state)
+	}
+}
+
+func TestCallerReleasesBreakerOnCallerCancel(t *testing.T) {
+	c := newTestCaller(1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	attempts := 0
+	err := c.call(ctx, "get_product", func(ctx context.Context) (bool, error) {
+		attempts++
+		cancel()
+		return hang(ctx)
+	})
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want no retry after cancel", attempts)
+	}
+	if c.breaker.state != breakerClosed || c.breaker.failures != 0 {
+		t.Errorf("breaker state/failures = %d/%d, want closed/0", c.breaker.state, c.breaker.failures)
+	}
+}
+
+func TestAttemptTimeoutSplitsRequestDeadline(t *testing.T) {
+	c := newTestCaller(1)
+	if got := c.attemptTimeout(context.Background(), 3); got != 2*time.Second {
+		t.Errorf("without deadline = %v, want configured 2s", got)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 900*time.Millisecond)
+	defer cancel()
+	if got := c.attemptTimeout(ctx, 3); got > 300*time.Millisecond || got < 250*time.Millisecond {
+		t.Errorf("3 attempts in 900ms = %v, want about 300ms", got)
+	}
+	if got := c.attemptTimeout(ctx, 1); got != 2*time.Second {
+		t.Errorf("last attempt = %v, want configured timeout bounded by the caller context", got)
+	}
+}
@@ -0,0 +1,81 @@
+package productclient
+
+import (
+	"context"
+	"fmt"
+	"orderfc/models"
+	"sync"
+)
+
+// FakeCatalog 메모리 상품 목록. productfc 없이 체크아웃을 테스트할 때 쓴다.
+type FakeCatalog struct {
+	mu       sync.RWMutex
+	products map[int64]models.Product
+	err      error
+	calls    int
+}
+
+func NewFakeCatalog(products ...models.Product) *FakeCatalog {
+	f := &FakeCatalog{products: make(map[int64]models.Product, len(products))}
+	for _, product := range products {
+		f.products[product.ID] = product
+	}
+	return f
+}
+
+// Set 상품을 추가하거나 재고/가격을 바꾼다.
+func (f *FakeCatalog) Set(product models.Product) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.products[product.ID] = product
+}
+
+func (f *FakeCatalog) Delete(productID int64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.products, productID)
+}
+
+// FailWith 이후 모든 조회가 err를 반환한다 (nil이면 해제). 예: ErrUnavailable.
+func (f *FakeCatalog) FailWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Calls 지금까지 받은 조회 요청 수 (배치 조회는 1회).
+func (f *FakeCatalog) Calls() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.calls
+}
+
+func (f *FakeCatalog) GetProduct(ctx context.Context, productID int64) (models.Product, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return models.Product{}, f.err
+	}
+	product, ok := f.products[productID]
+	if !ok {
+		return models.Product{}, fmt.Errorf("%w: %d", ErrNotFound, productID)
+	}
+	return product, nil
+}
+
+func (f *FakeCatalog) GetProducts(ctx context.Context, productIDs []int64) (map[int64]models.Product, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	products := make(map[int64]models.Product, len(productIDs))
+	for _, productID := range productIDs {
+		if product, ok := f.products[productID]; ok {
+			products[productID] = product
+		}
+	}
+	return products, nil
+}
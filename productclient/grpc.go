@@ -0,0 +1,109 @@
+package productclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"orderfc/config"
+	"orderfc/models"
+	productv1 "orderfc/proto/product/v1"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCClient productfc gRPC API (product.v1.ProductService).
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client productv1.ProductServiceClient
+	caller *caller
+}
+
+// NewGRPCClient 연결은 첫 호출 때 맺어진다.
+func NewGRPCClient(cfg config.ProductConfig) (*GRPCClient, error) {
+	if cfg.GRPCAddr == "" {
+		return nil, errors.New("product.grpc_addr is required for grpc transport")
+	}
+	conn, err := grpc.Dial(cfg.GRPCAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	return &GRPCClient{
+		conn:   conn,
+		client: productv1.NewProductServiceClient(conn),
+		caller: newCaller(TransportGRPC, cfg),
+	}, nil
+}
+
+func (c *GRPCClient) GetProduct(ctx context.Context, productID int64) (models.Product, error) {
+	var product *productv1.Product
+	err := c.caller.call(ctx, "get_product", func(ctx context.Context) (bool, error) {
+		var err error
+		product, err = c.client.GetProduct(ctx, &productv1.GetProductRequest{Id: productID})
+		return classifyGRPCError(err)
+	}, attribute.Int64("product.id", productID))
+	if errors.Is(err, ErrNotFound) {
+		return models.Product{}, fmt.Errorf("%w: %d", ErrNotFound, productID)
+	}
+	if err != nil {
+		return models.Product{}, err
+	}
+	return fromProto(product), nil
+}
+
+func (c *GRPCClient) GetProducts(ctx context.Context, productIDs []int64) (map[int64]models.Product, error) {
+	var resp *productv1.BatchGetProductsResponse
+	err := c.caller.call(ctx, "get_products", func(ctx context.Context) (bool, error) {
+		var err error
+		resp, err = c.client.BatchGetProducts(ctx, &productv1.BatchGetProductsRequest{Ids: productIDs})
+		if status.Code(err) == codes.Unimplemented {
+			return false, ErrBatchUnsupported
+		}
+		return classifyGRPCError(err)
+	}, attribute.Int("product.count", len(productIDs)))
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[int64]models.Product, len(resp.GetProducts()))
+	for _, product := range resp.GetProducts() {
+		products[product.GetId()] = fromProto(product)
+	}
+	return products, nil
+}
+
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// classifyGRPCError 일시적인 상태 코드만 재시도한다.
+func classifyGRPCError(err error) (retryable bool, _ error) {
+	if err == nil {
+		return false, nil
+	}
+	switch status.Code(err) {
+	case codes.NotFound:
+		return false, ErrNotFound
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	case codes.Canceled:
+		return false, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	default:
+		return false, fmt.Errorf("%w: %v", ErrBadResponse, err)
+	}
+}
+
+func fromProto(product *productv1.Product) models.Product {
+	return models.Product{
+		ID:          product.GetId(),
+		Name:        product.GetName(),
+		SKU:         product.GetSku(),
+		Description: product.GetDescription(),
+		Price:       product.GetPrice(),
+		Stock:       int(product.GetStock()),
+		CategoryID:  int(product.GetCategoryId()),
+		Weight:      int(product.GetWeight()),
+	}
+}
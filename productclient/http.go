@@ -0,0 +1,110 @@
+package productclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"orderfc/config"
+	"orderfc/models"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HTTPClient productfc REST API (GET /v1/products/:id, GET /v1/products?ids=).
+type HTTPClient struct {
+	host       string
+	httpClient *http.Client
+	caller     *caller
+}
+
+func NewHTTPClient(cfg config.ProductConfig) *HTTPClient {
+	return &HTTPClient{
+		host:       strings.TrimRight(cfg.Host, "/"),
+		httpClient: &http.Client{},
+		caller:     newCaller(TransportHTTP, cfg),
+	}
+}
+
+func (c *HTTPClient) GetProduct(ctx context.Context, productID int64) (models.Product, error) {
+	var product models.Product
+	err := c.caller.call(ctx, "get_product", func(ctx context.Context) (bool, error) {
+		return c.get(ctx, fmt.Sprintf("/v1/products/%d", productID), &product)
+	}, attribute.Int64("product.id", productID))
+	if errors.Is(err, ErrNotFound) {
+		return models.Product{}, fmt.Errorf("%w: %d", ErrNotFound, productID)
+	}
+	if err != nil {
+		return models.Product{}, err
+	}
+	return product, nil
+}
+
+// GetProducts 응답에 없는 ID는 존재하지 않는 상품이다.
+func (c *HTTPClient) GetProducts(ctx context.Context, productIDs []int64) (map[int64]models.Product, error) {
+	ids := make([]string, 0, len(productIDs))
+	for _, productID := range productIDs {
+		ids = append(ids, strconv.FormatInt(productID, 10))
+	}
+	var batch []models.Product
+	err := c.caller.call(ctx, "get_products", func(ctx context.Context) (bool, error) {
+		retryable, err := c.get(ctx, "/v1/products?ids="+strings.Join(ids, ","), &batch)
+		// 배치 API가 없는 productfc는 404/405/501로 응답한다. 재시도나 브레이커 실패로 세지 않는다.
+		var statusErr *StatusError
+		if errors.Is(err, ErrNotFound) || (errors.As(err, &statusErr) &&
+			(statusErr.StatusCode == http.StatusMethodNotAllowed || statusErr.StatusCode == http.StatusNotImplemented)) {
+			return false, ErrBatchUnsupported
+		}
+		return retryable, err
+	}, attribute.Int("product.count", len(productIDs)))
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[int64]models.Product, len(batch))
+	for _, product := range batch {
+		products[product.ID] = product
+	}
+	return products, nil
+}
+
+func (c *HTTPClient) get(ctx context.Context, path string, out any) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.host+path, nil)
+	if err != nil {
+		return false, err
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		io.Copy(io.Discard, resp.Body)
+		return false, ErrNotFound
+	case resp.StatusCode == http.StatusNotImplemented:
+		// 지원하지 않는 API라 재시도해도 결과가 같다.
+		io.Copy(io.Discard, resp.Body)
+		return false, fmt.Errorf("%w: %w", ErrBadResponse, &StatusError{StatusCode: resp.StatusCode})
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		io.Copy(io.Discard, resp.Body)
+		return true, fmt.Errorf("%w: %w", ErrUnavailable, &StatusError{StatusCode: resp.StatusCode})
+	default:
+		io.Copy(io.Discard, resp.Body)
+		return false, fmt.Errorf("%w: %w", ErrBadResponse, &StatusError{StatusCode: resp.StatusCode})
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return false, fmt.Errorf("%w: %v", ErrBadResponse, err)
+	}
+	return false, nil
+}
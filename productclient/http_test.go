@@ -0,0 +1,76 @@
+package productclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"orderfc/config"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatusClassification(t *testing.T) {
+	tests := []struct {
+		status       int
+		wantErr      error
+		wantAttempts int
+	}{
+		{status: http.StatusOK, wantAttempts: 1},
+		{status: http.StatusNotFound, wantErr: ErrNotFound, wantAttempts: 1},
+		{status: http.StatusBadRequest, wantErr: ErrBadResponse, wantAttempts: 1},
+		{status: http.StatusNotImplemented, wantErr: ErrBadResponse, wantAttempts: 1},
+		{status: http.StatusTooManyRequests, wantErr: ErrUnavailable, wantAttempts: 3},
+		{status: http.StatusServiceUnavailable, wantErr: ErrUnavailable, wantAttempts: 3},
+	}
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			attempts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"id":1,"price":1000}`))
+			}))
+			defer server.Close()
+			client := NewHTTPClient(config.ProductConfig{
+				Host:           server.URL,
+				RetryBaseDelay: time.Millisecond,
+				RetryMaxDelay:  time.Millisecond,
+			})
+
+			_, err := client.GetProduct(context.Background(), 1)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestClassifyGRPCError(t *testing.T) {
+	tests := []struct {
+		code          codes.Code
+		wantRetryable bool
+		wantErr       error
+	}{
+		{code: codes.NotFound, wantErr: ErrNotFound},
+		{code: codes.Unavailable, wantRetryable: true, wantErr: ErrUnavailable},
+		{code: codes.DeadlineExceeded, wantRetryable: true, wantErr: ErrUnavailable},
+		{code: codes.ResourceExhausted, wantRetryable: true, wantErr: ErrUnavailable},
+		{code: codes.Canceled, wantErr: ErrUnavailable},
+		{code: codes.InvalidArgument, wantErr: ErrBadResponse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			retryable, err := classifyGRPCError(status.Error(tt.code, "boom"))
+			if retryable != tt.wantRetryable || !errors.Is(err, tt.wantErr) {
+				t.Errorf("classify = %v, %v; want %v, %v", retryable, err, tt.wantRetryable, tt.wantErr)
+			}
+		})
+	}
+}
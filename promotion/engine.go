@@ -0,0 +1,143 @@
+// Package promotion 체크아웃 시 쿠폰/프로모션 할인 금액을 계산한다.
+// 저장(사용 횟수 차감, 주문별 할인 내역)은 주문 트랜잭션에서 service 계층이 담당한다.
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"sort"
+	"time"
+)
+
+var (
+	ErrCouponNotFound      = errors.New("coupon not found")
+	ErrCouponExpired       = errors.New("coupon is expired or not active")
+	ErrMinSpendNotMet      = errors.New("order does not meet coupon minimum spend")
+	ErrUsageLimitReached   = errors.New("coupon usage limit reached")
+	ErrCouponNotApplicable = errors.New("coupon is not applicable to this order")
+)
+
+// 할인 적용 순서. 같은 순위 안에서는 프로모션 ID 순.
+var typeOrder = map[string]int{
+	models.PromotionTypeBuyXGetY:   0,
+	models.PromotionTypePercentage: 1,
+	models.PromotionTypeFixed:      2,
+}
+
+type Line struct {
+	ProductID int64
+	Quantity  int
+	UnitPrice money.Amount
+}
+
+type Cart struct {
+	Lines []Line
+	Now   time.Time
+	// ToOrderCurrency 기준 통화로 정의된 프로모션 금액(AmountOff, MinSpend)을 주문 통화로 환산한다.
+	ToOrderCurrency func(money.Amount) money.Amount
+}
+
+func (c Cart) Subtotal() money.Amount {
+	var subtotal money.Amount
+	for _, line := range c.Lines {
+		subtotal += line.UnitPrice.Mul(line.Quantity)
+	}
+	return subtotal
+}
+
+// Candidate 평가 대상 프로모션. Explicit이면 사용자가 직접 입력한 쿠폰이라 적용할 수 없을 때 에러를 돌려준다.
+type Candidate struct {
+	Promotion       models.Promotion
+	Explicit        bool
+	UserRedemptions int
+}
+
+// Evaluate 적용 가능한 할인을 순서대로 계산한다 (buy_x_get_y -> percentage -> fixed).
+// 각 할인은 앞선 할인을 뺀 잔액 기준으로 계산되며 총 할인은 소계를 넘지 않는다.
+func Evaluate(cart Cart, candidates []Candidate) ([]models.OrderDiscount, error) {
+	sorted := make([]Candidate, len(candidates))
+	copy(sorted, candidates)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Promotion, sorted[j].Promotion
+		if typeOrder[a.Type] != typeOrder[b.Type] {
+			return typeOrder[a.Type] < typeOrder[b.Type]
+		}
+		return a.ID < b.ID
+	})
+
+	subtotal := cart.Subtotal()
+	remaining := subtotal
+	var discounts []models.OrderDiscount
+	for _, candidate := range sorted {
+		amount, err := discountFor(cart, candidate, subtotal, remaining)
+		if err != nil {
+			if candidate.Explicit {
+				return nil, fmt.Errorf("%w: %s", err, candidate.Promotion.Code)
+			}
+			continue
+		}
+		if amount > remaining {
+			amount = remaining
+		}
+		remaining -= amount
+		discounts = append(discounts, models.OrderDiscount{
+			PromotionID: candidate.Promotion.ID,
+			Code:        candidate.Promotion.Code,
+			Type:        candidate.Promotion.Type,
+			Amount:      amount,
+		})
+	}
+	return discounts, nil
+}
+
+func discountFor(cart Cart, candidate Candidate, subtotal, remaining money.Amount) (money.Amount, error) {
+	p := candidate.Promotion
+	if !p.Active || cart.Now.Before(p.StartTime) || !cart.Now.Before(p.ExpireTime) {
+		return 0, ErrCouponExpired
+	}
+	if p.MaxRedemptions > 0 && p.RedeemedCount >= p.MaxRedemptions {
+		return 0, ErrUsageLimitReached
+	}
+	if p.PerUserLimit > 0 && candidate.UserRedemptions >= p.PerUserLimit {
+		return 0, ErrUsageLimitReached
+	}
+	if p.MinSpend > 0 && subtotal < cart.ToOrderCurrency(p.MinSpend) {
+		return 0, ErrMinSpendNotMet
+	}
+
+	var amount money.Amount
+	switch p.Type {
+	case models.PromotionTypePercentage:
+		amount = remaining.MulRatio(int64(p.PercentBps), 10000)
+	case models.PromotionTypeFixed:
+		amount = cart.ToOrderCurrency(p.AmountOff)
+	case models.PromotionTypeBuyXGetY:
+		amount = buyXGetYDiscount(cart, p)
+	}
+	if amount <= 0 {
+		return 0, ErrCouponNotApplicable
+	}
+	return amount, nil
+}
+
+// buyXGetYDiscount BuyQty개 구매 시 GetQty개 무료. (BuyQty+GetQty) 묶음마다 GetQty개 단가만큼 할인한다.
+func buyXGetYDiscount(cart Cart, p models.Promotion) money.Amount {
+	if p.BuyQty <= 0 || p.GetQty <= 0 {
+		return 0
+	}
+	var qty int
+	var unitPrice money.Amount
+	for _, line := range cart.Lines {
+		if line.ProductID != p.ProductID {
+			continue
+		}
+		qty += line.Quantity
+		if unitPrice == 0 || line.UnitPrice < unitPrice {
+			unitPrice = line.UnitPrice
+		}
+	}
+	freeQty := qty / (p.BuyQty + p.GetQty) * p.GetQty
+	return unitPrice.Mul(freeQty)
+}
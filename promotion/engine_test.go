@@ -0,0 +1,121 @@
+package promotion
+
+import (
+	"errors"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func testPromotion(id int64, promotionType string) models.Promotion {
+	return models.Promotion{
+		ID:         id,
+		Code:       "P" + string(rune('0'+id)),
+		Type:       promotionType,
+		Active:     true,
+		StartTime:  testNow.Add(-time.Hour),
+		ExpireTime: testNow.Add(time.Hour),
+	}
+}
+
+func percentage(id int64, bps int) models.Promotion {
+	p := testPromotion(id, models.PromotionTypePercentage)
+	p.PercentBps = bps
+	return p
+}
+
+func fixed(id int64, amount money.Amount) models.Promotion {
+	p := testPromotion(id, models.PromotionTypeFixed)
+	p.AmountOff = amount
+	return p
+}
+
+func buyXGetY(id, productID int64, buy, get int) models.Promotion {
+	p := testPromotion(id, models.PromotionTypeBuyXGetY)
+	p.ProductID = productID
+	p.BuyQty = buy
+	p.GetQty = get
+	return p
+}
+
+// testCart 상품 1: 10000 x qty, 상품 2: 5000 x 1. 기준 통화 -> 주문 통화 환산은 rate배.
+func testCart(qty int, rate int64) Cart {
+	return Cart{
+		Lines: []Line{
+			{ProductID: 1, Quantity: qty, UnitPrice: 10000},
+			{ProductID: 2, Quantity: 1, UnitPrice: 5000},
+		},
+		Now:             testNow,
+		ToOrderCurrency: func(amount money.Amount) money.Amount { return amount * money.Amount(rate) },
+	}
+}
+
+func TestEvaluateDiscounts(t *testing.T) {
+	exhausted := fixed(1, 1000)
+	exhausted.MaxRedemptions = 5
+	exhausted.RedeemedCount = 5
+	minSpend := fixed(1, 1000)
+	minSpend.MinSpend = 100000
+	expired := fixed(1, 1000)
+	expired.ExpireTime = testNow
+
+	tests := []struct {
+		name       string
+		cart       Cart
+		candidates []Candidate
+		want       []money.Amount
+		wantErr    error
+	}{
+		{name: "percentage", cart: testCart(3, 1), candidates: []Candidate{{Promotion: percentage(1, 1000)}}, want: []money.Amount{3500}},
+		{name: "percentage rounds half away from zero", cart: testCart(3, 1), candidates: []Candidate{{Promotion: percentage(1, 333)}}, want: []money.Amount{1166}},
+		{name: "fixed", cart: testCart(3, 1), candidates: []Candidate{{Promotion: fixed(1, 2000)}}, want: []money.Amount{2000}},
+		{name: "fixed converted to order currency", cart: testCart(3, 2), candidates: []Candidate{{Promotion: fixed(1, 2000)}}, want: []money.Amount{4000}},
+		{name: "fixed capped at subtotal", cart: testCart(3, 1), candidates: []Candidate{{Promotion: fixed(1, 50000)}}, want: []money.Amount{35000}},
+		{name: "buy 2 get 1 with 3", cart: testCart(3, 1), candidates: []Candidate{{Promotion: buyXGetY(1, 1, 2, 1)}}, want: []money.Amount{10000}},
+		{name: "buy 2 get 1 with 5", cart: testCart(5, 1), candidates: []Candidate{{Promotion: buyXGetY(1, 1, 2, 1)}}, want: []money.Amount{10000}},
+		{name: "buy 2 get 1 with 6", cart: testCart(6, 1), candidates: []Candidate{{Promotion: buyXGetY(1, 1, 2, 1)}}, want: []money.Amount{20000}},
+		{name: "buy 2 get 1 not reached", cart: testCart(2, 1), candidates: []Candidate{{Promotion: buyXGetY(1, 1, 2, 1)}}},
+		{
+			// buy_x_get_y -> percentage -> fixed 순으로 앞선 할인을 뺀 잔액에 적용한다.
+			name: "stacked in type order",
+			cart: testCart(3, 1),
+			candidates: []Candidate{
+				{Promotion: fixed(1, 2000)},
+				{Promotion: percentage(2, 1000)},
+				{Promotion: buyXGetY(3, 1, 2, 1)},
+			},
+			want: []money.Amount{10000, 2500, 2000},
+		},
+		{name: "auto exhausted is skipped", cart: testCart(3, 1), candidates: []Candidate{{Promotion: exhausted}}},
+		{name: "explicit exhausted fails", cart: testCart(3, 1), candidates: []Candidate{{Promotion: exhausted, Explicit: true}}, wantErr: ErrUsageLimitReached},
+		{
+			name:       "explicit per-user limit fails",
+			cart:       testCart(3, 1),
+			candidates: []Candidate{{Promotion: func() models.Promotion { p := fixed(1, 1000); p.PerUserLimit = 1; return p }(), Explicit: true, UserRedemptions: 1}},
+			wantErr:    ErrUsageLimitReached,
+		},
+		{name: "auto min spend is skipped", cart: testCart(3, 1), candidates: []Candidate{{Promotion: minSpend}}},
+		{name: "explicit min spend fails", cart: testCart(3, 1), candidates: []Candidate{{Promotion: minSpend, Explicit: true}}, wantErr: ErrMinSpendNotMet},
+		{name: "explicit expired fails", cart: testCart(3, 1), candidates: []Candidate{{Promotion: expired, Explicit: true}}, wantErr: ErrCouponExpired},
+		{name: "explicit not applicable fails", cart: testCart(3, 1), candidates: []Candidate{{Promotion: buyXGetY(1, 9, 1, 1), Explicit: true}}, wantErr: ErrCouponNotApplicable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discounts, err := Evaluate(tt.cart, tt.candidates)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(discounts) != len(tt.want) {
+				t.Fatalf("discounts = %+v, want amounts %v", discounts, tt.want)
+			}
+			for i, discount := range discounts {
+				if discount.Amount != tt.want[i] {
+					t.Errorf("discount %d (%s) = %d, want %d", i, discount.Type, discount.Amount, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package productv1 productfc gRPC 계약. product.proto를 고친 뒤 저장소 루트에서 다시 생성한다:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative proto/product/v1/product.proto
+package productv1
@@ -6,6 +6,7 @@ import (
 	"orderfc/cmd/order/handler"
 	"orderfc/cmd/order/resource"
 	"orderfc/config"
+	"orderfc/infrastructure/audit"
 	"orderfc/middleware"
 	"time"
 
@@ -82,10 +83,38 @@ func SetupRoutes(router *gin.Engine, orderHandler *handler.OrderHandler, db *gor
 
 	// private API (인증 필요)
 	private := router.Group("/api")
-	private.Use(middleware.AuthMiddleware(config.GetJwtSecret()))
+	private.Use(middleware.AuthMiddleware(config.GetJwtSecret()), middleware.AuditActor(audit.ActorUser))
 	{
 		private.POST("/v1/orders", orderHandler.CheckOutOrder)
+		private.POST("/v1/orders/quote", orderHandler.QuoteOrder)
+		private.POST("/v1/orders/:id/cancel", orderHandler.CancelOrder)
 		private.GET("/v1/orders/history", orderHandler.GetOrderHistoryByUserId)
 		private.GET("/v1/orders/sales-report", orderHandler.GetSalesReport)
+		private.GET("/v1/orders/:id", orderHandler.GetOrderByID)
+		private.PATCH("/v1/orders/:id", orderHandler.AmendOrder)
+		private.POST("/v1/orders/:id/reorder", orderHandler.Reorder)
+
+		private.GET("/v1/cart", orderHandler.GetCart)
+		private.PUT("/v1/cart", orderHandler.ReplaceCart)
+		private.DELETE("/v1/cart", orderHandler.ClearCart)
+		private.POST("/v1/cart/items", orderHandler.AddCartItem)
+		private.PUT("/v1/cart/items/:product_id", orderHandler.UpdateCartItem)
+		private.DELETE("/v1/cart/items/:product_id", orderHandler.RemoveCartItem)
+		private.POST("/v1/cart/checkout", orderHandler.CheckOutCart)
+	}
+
+	// admin API (role=admin|support). 조회는 support도 가능하고, 상태를 바꾸는 API는 admin만 허용한다. 주문 상태 변경은 orders:write scope도 필요하다.
+	admin := router.Group("/api/admin")
+	admin.Use(middleware.AuthMiddleware(config.GetJwtSecret()), middleware.RequireRole("admin", "support"), middleware.AuditActor(audit.ActorAdmin))
+	adminOnly := middleware.RequireRole("admin")
+	{
+		admin.GET("/v1/orders", orderHandler.SearchOrders)
+		admin.GET("/v1/orders/search", orderHandler.FullTextSearchOrders)
+		admin.GET("/v1/orders/:id", orderHandler.GetAdminOrderByID)
+		admin.POST("/v1/orders/:id/status", adminOnly, middleware.RequireScope("orders:write"), orderHandler.OverrideOrderStatus)
+		admin.POST("/v1/orders/:id/ship", adminOnly, orderHandler.ShipOrder)
+		admin.GET("/v1/orders/:id/saga", orderHandler.GetOrderSaga)
+		admin.GET("/v1/orders/:id/audit-log", orderHandler.GetOrderAuditLog)
+		admin.DELETE("/v1/products/:id/cache", adminOnly, orderHandler.InvalidateProductCache)
 	}
 }
@@ -0,0 +1,271 @@
+// Package saga 체크아웃 saga 상태 머신. 영속화와 step 실행은 OrderService가 담당하고,
+// 이 패키지는 이벤트에 따른 step 상태 변화만 계산한다.
+//
+// 정방향: reserve_stock (order.created) -> request_payment (processing 전이 + payment.requested) -> confirm_order
+// 보상:   cancel_order -> release_stock (재고 예약이 거절된 경우 release_stock 생략)
+// 취소 이후 늦게 도착한 결제 완료는 refund_payment, 재고 예약은 release_stock 보상을 ApplyLate가 추가한다.
+package saga
+
+import (
+	"errors"
+	"fmt"
+	"orderfc/models"
+)
+
+var (
+	ErrSagaFinished    = errors.New("saga already finished")
+	ErrUnexpectedEvent = errors.New("event does not match saga state")
+)
+
+// 외부 서비스 응답 이벤트. 값은 원본 Kafka 토픽명.
+const (
+	EventStockReserved    = "stock.reserved"
+	EventStockMismatch    = "stock.reserved.amount_mismatch" // 예약 금액이 주문 금액과 다름. 예약된 재고를 해제하고 취소한다
+	EventStockRejected    = "stock.rejected"
+	EventPaymentSucceeded = "payment.success"
+	EventPaymentFailed    = "payment.failed"
+)
+
+type Event struct {
+	Type   string
+	Reason string
+}
+
+// Start 체크아웃 시점 초기 상태. order.created가 productfc 재고 예약 커맨드이므로 reserve_stock은 응답 대기로 시작한다.
+func Start(orderID int64) *models.OrderSaga {
+	return &models.OrderSaga{
+		OrderID:     orderID,
+		Status:      models.SagaStatusRunning,
+		CurrentStep: models.SagaStepReserveStock,
+		Steps: []models.OrderSagaStep{
+			{Step: models.SagaStepReserveStock, Status: models.SagaStepStatusWaiting},
+		},
+	}
+}
+
+func IsFinished(sg *models.OrderSaga) bool {
+	switch sg.Status {
+	case models.SagaStatusCompleted, models.SagaStatusCompensated, models.SagaStatusFailed:
+		return true
+	}
+	return false
+}
+
+// Apply 응답 이벤트를 반영한다. 실행이 필요한 step은 pending으로 추가되고 OrderService가 실행한다.
+func Apply(sg *models.OrderSaga, event Event) error {
+	if IsFinished(sg) {
+		return ErrSagaFinished
+	}
+	if sg.Status == models.SagaStatusCompensating {
+		return unexpectedEvent(sg, event, nil)
+	}
+
+	switch event.Type {
+	case EventStockReserved:
+		step := findStep(sg, models.SagaStepReserveStock)
+		if step == nil || step.Status != models.SagaStepStatusWaiting {
+			return unexpectedEvent(sg, event, step)
+		}
+		step.Status = models.SagaStepStatusSucceeded
+		sg.Steps = append(sg.Steps, models.OrderSagaStep{Step: models.SagaStepRequestPayment, Status: models.SagaStepStatusPending})
+		sg.CurrentStep = models.SagaStepRequestPayment
+
+	case EventStockMismatch:
+		step := findStep(sg, models.SagaStepReserveStock)
+		if step == nil || step.Status != models.SagaStepStatusWaiting {
+			return unexpectedEvent(sg, event, step)
+		}
+		step.Status = models.SagaStepStatusFailed
+		step.Detail = event.Reason
+		compensate(sg, event.Reason, true)
+
+	case EventStockRejected:
+		step := findStep(sg, models.SagaStepReserveStock)
+		if step == nil || step.Status != models.SagaStepStatusWaiting {
+			return unexpectedEvent(sg, event, step)
+		}
+		step.Status = models.SagaStepStatusFailed
+		step.Detail = event.Reason
+		compensate(sg, event.Reason, false)
+
+	case EventPaymentSucceeded:
+		step, err := paymentStep(sg, event)
+		if err != nil {
+			return err
+		}
+		step.Status = models.SagaStepStatusSucceeded
+		// 결제가 먼저 응답해도 재고 거절이 없었다면 예약된 것으로 본다 (기존 흐름과 동일).
+		if reserve := findStep(sg, models.SagaStepReserveStock); reserve != nil && reserve.Status == models.SagaStepStatusWaiting {
+			reserve.Status = models.SagaStepStatusSucceeded
+			reserve.Detail = "implied by " + EventPaymentSucceeded
+		}
+		sg.Steps = append(sg.Steps, models.OrderSagaStep{Step: models.SagaStepConfirmOrder, Status: models.SagaStepStatusPending})
+		sg.CurrentStep = models.SagaStepConfirmOrder
+
+	case EventPaymentFailed:
+		step, err := paymentStep(sg, event)
+		if err != nil {
+			return err
+		}
+		step.Status = models.SagaStepStatusFailed
+		step.Detail = event.Reason
+		compensate(sg, event.Reason, true)
+
+	default:
+		return fmt.Errorf("%w: unknown event %s", ErrUnexpectedEvent, event.Type)
+	}
+	return nil
+}
+
+// Abort saga 외부에서 주문이 이미 취소된 경우(고객 취소, 결제 시간 초과) 진행 중인 step을 중단하고 보상 완료로 닫는다.
+func Abort(sg *models.OrderSaga, reason string) {
+	if sg.Status != models.SagaStatusRunning {
+		return
+	}
+	abortForwardSteps(sg)
+	sg.Steps = append(sg.Steps, models.OrderSagaStep{
+		Step:         models.SagaStepCancelOrder,
+		Compensation: true,
+		Status:       models.SagaStepStatusSucceeded,
+		Detail:       reason,
+	})
+	sg.Status = models.SagaStatusCompensated
+	sg.CurrentStep = models.SagaStepCancelOrder
+	sg.LastError = reason
+}
+
+// ApplyLate 보상으로 닫혔거나 보상 중인 saga에 늦게 도착한 응답 중 효과를 되돌려야 하는 것에 보상 step을 추가하고
+// saga를 다시 보상 중으로 연다. 같은 보상 step이 이미 있으면(중복 수신) 아무것도 바꾸지 않고 false를 반환한다.
+func ApplyLate(sg *models.OrderSaga, event Event) bool {
+	if sg.Status != models.SagaStatusCompensated && sg.Status != models.SagaStatusCompensating {
+		return false
+	}
+	var step string
+	switch event.Type {
+	case EventPaymentSucceeded:
+		step = models.SagaStepRefundPayment
+	case EventStockReserved, EventStockMismatch:
+		// 예약 응답 전에 취소되어 stock.rollback을 보내지 않은 경우만 해제한다.
+		reserve := findStep(sg, models.SagaStepReserveStock)
+		if reserve == nil || reserve.Status != models.SagaStepStatusAborted {
+			return false
+		}
+		step = models.SagaStepReleaseStock
+	default:
+		return false
+	}
+	for _, existing := range sg.Steps {
+		if existing.Step == step && existing.Compensation {
+			return false
+		}
+	}
+	sg.Steps = append(sg.Steps, models.OrderSagaStep{Step: step, Compensation: true, Status: models.SagaStepStatusPending, Detail: "late " + event.Type})
+	sg.Status = models.SagaStatusCompensating
+	sg.CurrentStep = step
+	return true
+}
+
+// StockReserved productfc가 재고 예약을 확인했는지. 확인 전에 취소된 주문은 되돌릴 재고가 없다.
+func StockReserved(sg *models.OrderSaga) bool {
+	reserve := findStep(sg, models.SagaStepReserveStock)
+	return reserve != nil && reserve.Status == models.SagaStepStatusSucceeded
+}
+
+// Settle pending step 실행 후 saga 최종 상태를 정한다.
+func Settle(sg *models.OrderSaga) {
+	for _, step := range sg.Steps {
+		if step.Status == models.SagaStepStatusFailed && (step.Compensation || step.Step == models.SagaStepConfirmOrder) {
+			sg.Status = models.SagaStatusFailed
+			for i := range sg.Steps {
+				if sg.Steps[i].Status == models.SagaStepStatusPending {
+					sg.Steps[i].Status = models.SagaStepStatusAborted
+				}
+			}
+			return
+		}
+	}
+	for _, step := range sg.Steps {
+		if step.Status == models.SagaStepStatusPending || step.Status == models.SagaStepStatusWaiting {
+			return
+		}
+	}
+	switch sg.Status {
+	case models.SagaStatusRunning:
+		sg.Status = models.SagaStatusCompleted
+	case models.SagaStatusCompensating:
+		sg.Status = models.SagaStatusCompensated
+	}
+}
+
+// PendingSteps 실행 대기 중인 step (생성 순서).
+func PendingSteps(sg *models.OrderSaga) []*models.OrderSagaStep {
+	var pending []*models.OrderSagaStep
+	for i := range sg.Steps {
+		if sg.Steps[i].Status == models.SagaStepStatusPending {
+			pending = append(pending, &sg.Steps[i])
+		}
+	}
+	return pending
+}
+
+func compensate(sg *models.OrderSaga, reason string, releaseStock bool) {
+	abortForwardSteps(sg)
+	sg.Steps = append(sg.Steps, models.OrderSagaStep{Step: models.SagaStepCancelOrder, Compensation: true, Status: models.SagaStepStatusPending})
+	if releaseStock {
+		sg.Steps = append(sg.Steps, models.OrderSagaStep{Step: models.SagaStepReleaseStock, Compensation: true, Status: models.SagaStepStatusPending})
+	}
+	sg.Status = models.SagaStatusCompensating
+	sg.CurrentStep = models.SagaStepCancelOrder
+	sg.LastError = reason
+}
+
+func abortForwardSteps(sg *models.OrderSaga) {
+	for i := range sg.Steps {
+		step := &sg.Steps[i]
+		if !step.Compensation && (step.Status == models.SagaStepStatusWaiting || step.Status == models.SagaStepStatusPending) {
+			step.Status = models.SagaStepStatusAborted
+		}
+	}
+}
+
+// paymentStep 결제 응답을 받을 request_payment step. paymentfc가 payment.requested 대신 order.created에
+// 직접 반응하던 이전 흐름의 응답도 받을 수 있도록, step이 아직 없으면 응답 대기 상태로 추가한다.
+// step이 아직 실행 전(pending)이어도 결제 결과가 먼저 왔으므로 그대로 받는다. 결과가 반영되면 step은
+// pending에서 벗어나 payment.requested를 다시 발행하지 않는다.
+func paymentStep(sg *models.OrderSaga, event Event) (*models.OrderSagaStep, error) {
+	step := findStep(sg, models.SagaStepRequestPayment)
+	if step == nil {
+		sg.Steps = append(sg.Steps, models.OrderSagaStep{
+			Step:   models.SagaStepRequestPayment,
+			Status: models.SagaStepStatusWaiting,
+			Detail: event.Type + " before payment.requested",
+		})
+		return &sg.Steps[len(sg.Steps)-1], nil
+	}
+	switch step.Status {
+	case models.SagaStepStatusWaiting:
+		return step, nil
+	case models.SagaStepStatusPending:
+		step.Detail = event.Type + " before payment.requested"
+		return step, nil
+	}
+	return nil, unexpectedEvent(sg, event, step)
+}
+
+// unexpectedEvent 드롭된 이벤트를 진단할 수 있도록 saga 상태와 대상 step 상태를 에러에 담는다.
+func unexpectedEvent(sg *models.OrderSaga, event Event, step *models.OrderSagaStep) error {
+	stepState := "none"
+	if step != nil {
+		stepState = step.Step + "=" + step.Status
+	}
+	return fmt.Errorf("%w: %s (saga %s at %s, step %s)", ErrUnexpectedEvent, event.Type, sg.Status, sg.CurrentStep, stepState)
+}
+
+func findStep(sg *models.OrderSaga, name string) *models.OrderSagaStep {
+	for i := range sg.Steps {
+		if sg.Steps[i].Step == name && !sg.Steps[i].Compensation {
+			return &sg.Steps[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Package shipping 체크아웃 배송비 계산. 요금 설정은 기준 통화 minor unit이다.
+package shipping
+
+import (
+	"context"
+	"orderfc/config"
+	"orderfc/infrastructure/money"
+	"strings"
+)
+
+const gramsPerKg = 1000
+
+type Input struct {
+	Country     string
+	Subtotal    money.Amount // 할인 후 상품 금액 (기준 통화 minor unit)
+	WeightGrams int
+}
+
+// ShippingCalculator 체크아웃 시 기준 통화 배송비를 계산한다.
+type ShippingCalculator interface {
+	Calculate(ctx context.Context, input Input) (money.Amount, error)
+}
+
+type rateRule struct {
+	flatFee  money.Amount
+	perKgFee money.Amount
+	freeOver money.Amount
+}
+
+// RuleBasedCalculator 국가별 기본 요금 + 무게(kg 올림) 요금을 적용하고, free_over 이상 주문은 무료 배송한다.
+// 등록되지 않은 국가는 default 요금을 사용한다. 국가가 없는 주소(구조화 이전 문자열 주소)는 세금과 같은
+// 기본 지역(homeCountry, tax.default_region)으로 계산한다.
+type RuleBasedCalculator struct {
+	defaultRule rateRule
+	countries   map[string]rateRule
+	homeCountry string
+}
+
+func NewRuleBasedCalculator(cfg config.ShippingConfig, homeCountry string) *RuleBasedCalculator {
+	c := &RuleBasedCalculator{
+		defaultRule: newRateRule(cfg.Default),
+		countries:   make(map[string]rateRule, len(cfg.Countries)),
+		homeCountry: strings.ToUpper(homeCountry),
+	}
+	for country, rateCfg := range cfg.Countries {
+		c.countries[strings.ToUpper(country)] = newRateRule(rateCfg)
+	}
+	return c
+}
+
+func newRateRule(cfg config.ShippingRateConfig) rateRule {
+	return rateRule{
+		flatFee:  money.Amount(cfg.FlatFee),
+		perKgFee: money.Amount(cfg.PerKgFee),
+		freeOver: money.Amount(cfg.FreeOver),
+	}
+}
+
+func (c *RuleBasedCalculator) Calculate(ctx context.Context, input Input) (money.Amount, error) {
+	country := strings.ToUpper(input.Country)
+	if country == "" {
+		country = c.homeCountry
+	}
+	rule, ok := c.countries[country]
+	if !ok {
+		rule = c.defaultRule
+	}
+	if rule.freeOver > 0 && input.Subtotal >= rule.freeOver {
+		return 0, nil
+	}
+
+	fee := rule.flatFee
+	if rule.perKgFee > 0 && input.WeightGrams > 0 {
+		kg := (input.WeightGrams + gramsPerKg - 1) / gramsPerKg
+		fee += rule.perKgFee.Mul(kg)
+	}
+	return fee, nil
+}
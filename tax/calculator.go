@@ -0,0 +1,120 @@
+// Package tax 체크아웃 세금 계산. 지역 설정에 따라 세금을 할인 후 금액에 별도 가산(exclusive)하거나
+// 세금이 포함된 가격에서 역산(inclusive)한다.
+package tax
+
+import (
+	"context"
+	"fmt"
+	"orderfc/config"
+	"orderfc/infrastructure/money"
+	"orderfc/models"
+	"strconv"
+	"strings"
+)
+
+const basisPoints = 10000
+
+type Line struct {
+	ProductID     int64
+	CategoryID    int
+	TaxableAmount money.Amount // 할인 배분 후 라인 금액 (주문 통화 minor unit). inclusive 지역이면 세금 포함 금액
+}
+
+type Input struct {
+	Region string
+	Lines  []Line
+}
+
+// TaxCalculator 체크아웃 시 라인별 세금을 계산한다.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, input Input) ([]models.OrderTaxLine, error)
+}
+
+type regionRule struct {
+	rateBps       int
+	inclusive     bool
+	categoryRates map[int]int
+}
+
+// RuleBasedCalculator 지역별 기본 세율과 카테고리별 예외 세율을 적용한다.
+// 지역이 비어 있거나(문자열 주소) 등록되지 않은 지역은 default_region 규칙을 사용한다.
+type RuleBasedCalculator struct {
+	defaultRegion string
+	regions       map[string]regionRule
+}
+
+func NewRuleBasedCalculator(cfg config.TaxConfig) (*RuleBasedCalculator, error) {
+	c := &RuleBasedCalculator{
+		defaultRegion: strings.ToUpper(cfg.DefaultRegion),
+		regions:       make(map[string]regionRule, len(cfg.Regions)),
+	}
+	for region, regionCfg := range cfg.Regions {
+		rule := regionRule{rateBps: regionCfg.RateBps, inclusive: regionCfg.Inclusive, categoryRates: make(map[int]int, len(regionCfg.CategoryRates))}
+		for category, rateBps := range regionCfg.CategoryRates {
+			categoryID, err := strconv.Atoi(category)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tax category %q for region %s", category, region)
+			}
+			rule.categoryRates[categoryID] = rateBps
+		}
+		c.regions[strings.ToUpper(region)] = rule
+	}
+	if _, ok := c.regions[c.defaultRegion]; !ok {
+		return nil, fmt.Errorf("tax rules for default region %q are not configured", c.defaultRegion)
+	}
+	return c, nil
+}
+
+func (c *RuleBasedCalculator) Calculate(ctx context.Context, input Input) ([]models.OrderTaxLine, error) {
+	region := strings.ToUpper(input.Region)
+	rule, ok := c.regions[region]
+	if !ok {
+		region = c.defaultRegion
+		rule = c.regions[region]
+	}
+
+	taxLines := make([]models.OrderTaxLine, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		rateBps := rule.rateBps
+		if categoryRate, ok := rule.categoryRates[line.CategoryID]; ok {
+			rateBps = categoryRate
+		}
+		taxLine := models.OrderTaxLine{
+			ProductID:  line.ProductID,
+			CategoryID: line.CategoryID,
+			Region:     region,
+			RateBps:    rateBps,
+			Inclusive:  rule.inclusive,
+		}
+		if rule.inclusive {
+			// 세금 포함 가격 = 공급가액 * (1 + 세율) 이므로 세금 = 가격 * 세율 / (1 + 세율).
+			taxLine.TaxAmount = line.TaxableAmount.MulRatio(int64(rateBps), basisPoints+int64(rateBps))
+			taxLine.TaxableAmount = line.TaxableAmount - taxLine.TaxAmount
+		} else {
+			taxLine.TaxableAmount = line.TaxableAmount
+			taxLine.TaxAmount = line.TaxableAmount.MulRatio(int64(rateBps), basisPoints)
+		}
+		taxLines = append(taxLines, taxLine)
+	}
+	return taxLines, nil
+}
+
+// SumTax 주문에 포함된 세금 합계 (inclusive + exclusive).
+func SumTax(taxLines []models.OrderTaxLine) money.Amount {
+	var total money.Amount
+	for _, line := range taxLines {
+		total += line.TaxAmount
+	}
+	return total
+}
+
+// SumExclusiveTax 가격에 포함되지 않아 주문 금액에 더해야 하는 세금 합계.
+func SumExclusiveTax(taxLines []models.OrderTaxLine) money.Amount {
+	var total money.Amount
+	for _, line := range taxLines {
+		if !line.Inclusive {
+			total += line.TaxAmount
+		}
+	}
+	return total
+}
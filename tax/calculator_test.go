@@ -0,0 +1,99 @@
+package tax
+
+import (
+	"context"
+	"orderfc/config"
+	"orderfc/infrastructure/money"
+	"testing"
+)
+
+func newTestCalculator(t *testing.T) *RuleBasedCalculator {
+	t.Helper()
+	c, err := NewRuleBasedCalculator(config.TaxConfig{
+		DefaultRegion: "kr",
+		Regions: map[string]config.TaxRegionConfig{
+			"KR": {RateBps: 1000, Inclusive: true, CategoryRates: map[string]int{"7": 0}},
+			"JP": {RateBps: 1000, Inclusive: true, CategoryRates: map[string]int{"1": 800}},
+			"US": {RateBps: 800, CategoryRates: map[string]int{"3": 0}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestCalculate(t *testing.T) {
+	c := newTestCalculator(t)
+	tests := []struct {
+		name        string
+		region      string
+		categoryID  int
+		amount      money.Amount
+		wantRegion  string
+		wantTax     money.Amount
+		wantTaxable money.Amount
+		inclusive   bool
+	}{
+		// 포함 세금 = 가격 * 세율 / (1 + 세율), 공급가액 = 가격 - 세금
+		{name: "KR inclusive exact", region: "KR", amount: 11000, wantRegion: "KR", wantTax: 1000, wantTaxable: 10000, inclusive: true},
+		{name: "KR inclusive rounds down", region: "KR", amount: 10000, wantRegion: "KR", wantTax: 909, wantTaxable: 9091, inclusive: true},
+		{name: "KR inclusive below half", region: "KR", amount: 5, wantRegion: "KR", wantTax: 0, wantTaxable: 5, inclusive: true},
+		{name: "KR inclusive above half rounds up", region: "KR", amount: 6, wantRegion: "KR", wantTax: 1, wantTaxable: 5, inclusive: true},
+		{name: "KR exempt category", region: "KR", categoryID: 7, amount: 10000, wantRegion: "KR", wantTax: 0, wantTaxable: 10000, inclusive: true},
+		{name: "JP reduced category", region: "jp", categoryID: 1, amount: 10800, wantRegion: "JP", wantTax: 800, wantTaxable: 10000, inclusive: true},
+		{name: "JP standard rate", region: "JP", amount: 1100, wantRegion: "JP", wantTax: 100, wantTaxable: 1000, inclusive: true},
+		{name: "US exclusive", region: "US", amount: 1000, wantRegion: "US", wantTax: 80, wantTaxable: 1000},
+		{name: "US exclusive rounds down", region: "US", amount: 1006, wantRegion: "US", wantTax: 80, wantTaxable: 1006},
+		{name: "US exclusive rounds up", region: "US", amount: 1007, wantRegion: "US", wantTax: 81, wantTaxable: 1007},
+		{name: "US exempt category", region: "US", categoryID: 3, amount: 1000, wantRegion: "US", wantTax: 0, wantTaxable: 1000},
+		{name: "unknown region uses default", region: "FR", amount: 11000, wantRegion: "KR", wantTax: 1000, wantTaxable: 10000, inclusive: true},
+		{name: "empty region uses default", region: "", amount: 11000, wantRegion: "KR", wantTax: 1000, wantTaxable: 10000, inclusive: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, err := c.Calculate(context.Background(), Input{
+				Region: tt.region,
+				Lines:  []Line{{ProductID: 1, CategoryID: tt.categoryID, TaxableAmount: tt.amount}},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			line := lines[0]
+			if line.Region != tt.wantRegion || line.Inclusive != tt.inclusive {
+				t.Errorf("region/inclusive = %s/%v, want %s/%v", line.Region, line.Inclusive, tt.wantRegion, tt.inclusive)
+			}
+			if line.TaxAmount != tt.wantTax || line.TaxableAmount != tt.wantTaxable {
+				t.Errorf("tax/taxable = %d/%d, want %d/%d", line.TaxAmount, line.TaxableAmount, tt.wantTax, tt.wantTaxable)
+			}
+		})
+	}
+}
+
+func TestSumExclusiveTaxSkipsInclusiveLines(t *testing.T) {
+	c := newTestCalculator(t)
+	kr, _ := c.Calculate(context.Background(), Input{Region: "KR", Lines: []Line{{TaxableAmount: 11000}}})
+	us, _ := c.Calculate(context.Background(), Input{Region: "US", Lines: []Line{{TaxableAmount: 1000}}})
+	lines := append(kr, us...)
+	if got := SumTax(lines); got != 1080 {
+		t.Errorf("SumTax = %d, want 1080", got)
+	}
+	if got := SumExclusiveTax(lines); got != 80 {
+		t.Errorf("SumExclusiveTax = %d, want 80", got)
+	}
+}
+
+func TestNewRuleBasedCalculatorRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.TaxConfig
+	}{
+		{name: "default region missing", cfg: config.TaxConfig{DefaultRegion: "KR", Regions: map[string]config.TaxRegionConfig{"US": {RateBps: 800}}}},
+		{name: "non-numeric category", cfg: config.TaxConfig{DefaultRegion: "KR", Regions: map[string]config.TaxRegionConfig{"KR": {RateBps: 1000, CategoryRates: map[string]int{"food": 0}}}}},
+	}
+	for _, tt := range tests {
+		if _, err := NewRuleBasedCalculator(tt.cfg); err == nil {
+			t.Errorf("%s: want error", tt.name)
+		}
+	}
+}
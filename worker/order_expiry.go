@@ -0,0 +1,68 @@
+package worker
+
+import (
+	"context"
+	"orderfc/cmd/order/service"
+	"orderfc/config"
+	"orderfc/infrastructure/audit"
+	"orderfc/infrastructure/log"
+	"time"
+)
+
+// OrderExpirySweeper 결제 응답 없이 payment timeout이 지난 주문을 주기적으로 취소한다.
+type OrderExpirySweeper struct {
+	OrderService   *service.OrderService
+	PaymentTimeout time.Duration
+	Interval       time.Duration
+	BatchSize      int
+}
+
+func NewOrderExpirySweeper(orderService *service.OrderService, cfg config.OrderConfig) *OrderExpirySweeper {
+	sweeper := &OrderExpirySweeper{
+		OrderService:   orderService,
+		PaymentTimeout: cfg.PaymentTimeout,
+		Interval:       cfg.SweepInterval,
+		BatchSize:      cfg.SweepBatchSize,
+	}
+	if sweeper.Interval <= 0 {
+		sweeper.Interval = time.Minute
+	}
+	if sweeper.BatchSize <= 0 {
+		sweeper.BatchSize = 50
+	}
+	return sweeper
+}
+
+func (w *OrderExpirySweeper) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	ctx = audit.ForJob(ctx, "order-expiry")
+	for {
+		w.sweep(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep 한 배치가 가득 차면 밀린 주문이 더 있다고 보고 바로 다음 배치를 처리한다.
+func (w *OrderExpirySweeper) sweep(ctx context.Context) {
+	for {
+		cutoff := time.Now().Add(-w.PaymentTimeout)
+		expired, err := w.OrderService.ExpireUnpaidOrders(ctx, cutoff, w.BatchSize)
+		if err != nil {
+			log.Logger.Error().Err(err).Msg("Failed to sweep unpaid orders")
+			return
+		}
+		if expired > 0 {
+			log.Logger.Info().Int("expired", expired).Msg("Cancelled unpaid orders after payment timeout")
+		}
+		if expired < w.BatchSize {
+			return
+		}
+	}
+}
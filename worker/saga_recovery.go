@@ -0,0 +1,58 @@
+package worker
+
+import (
+	"context"
+	"orderfc/cmd/order/service"
+	"orderfc/infrastructure/audit"
+	"orderfc/infrastructure/log"
+	"time"
+)
+
+// SagaRecoveryWorker 이벤트는 기록됐지만 step 실행이 끝나지 않은 saga(프로세스 재시작, 일시적 DB 오류)를 이어서 실행한다.
+// 시작 직후 한 번 실행하므로 재시작 전 진행 중이던 saga가 바로 재개된다.
+type SagaRecoveryWorker struct {
+	OrderService *service.OrderService
+	Interval     time.Duration
+	StaleAfter   time.Duration // 컨슈머가 바로 실행 중일 수 있는 saga는 건너뛴다
+	BatchSize    int
+}
+
+func NewSagaRecoveryWorker(orderService *service.OrderService) *SagaRecoveryWorker {
+	return &SagaRecoveryWorker{
+		OrderService: orderService,
+		Interval:     30 * time.Second,
+		StaleAfter:   30 * time.Second,
+		BatchSize:    50,
+	}
+}
+
+func (w *SagaRecoveryWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	ctx = audit.ForJob(ctx, "saga-recovery")
+	for {
+		w.recover(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *SagaRecoveryWorker) recover(ctx context.Context) {
+	orderIDs, err := w.OrderService.GetStalledSagaOrderIDs(ctx, time.Now().Add(-w.StaleAfter), w.BatchSize)
+	if err != nil {
+		log.Logger.Error().Err(err).Msg("Failed to fetch stalled sagas")
+		return
+	}
+	for _, orderID := range orderIDs {
+		if err := w.OrderService.RunSagaSteps(ctx, orderID); err != nil {
+			log.Logger.Error().Err(err).Int64("order_id", orderID).Msg("Failed to resume order saga")
+			continue
+		}
+		log.Logger.Info().Int64("order_id", orderID).Msg("Resumed order saga")
+	}
+}